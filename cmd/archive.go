@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/ulikunitz/xz"
+)
+
+// archive formats detected from file name
+const (
+	formatTar      = "tar"
+	formatTarGzip  = "tar.gz"
+	formatTarXz    = "tar.xz"
+	formatTarBzip2 = "tar.bz2"
+	formatZip      = "zip"
+	formatGzip     = "gz"
+	formatXz       = "xz"
+	formatRaw      = ""
+)
+
+const (
+	// minimum size of range request to read remote zip archive
+	remoteReadChunkSize = 256 * 1024
+)
+
+// errStopWalk stops walking archive without error
+var errStopWalk = errors.New("stop walking archive")
+
+var archiveSuffixes = []struct {
+	suffix string
+	format string
+}{
+	{".tar.gz", formatTarGzip},
+	{".tgz", formatTarGzip},
+	{".tar.xz", formatTarXz},
+	{".txz", formatTarXz},
+	{".tar.bz2", formatTarBzip2},
+	{".tbz2", formatTarBzip2},
+	{".tar", formatTar},
+	{".zip", formatZip},
+	{".gz", formatGzip},
+	{".xz", formatXz},
+}
+
+// archiveEntry represents a regular file in archive
+type archiveEntry struct {
+	Name string
+	Size int64
+	Mode os.FileMode
+}
+
+// detectArchiveFormat returns archive format of the given file name, or formatRaw for plain file
+func detectArchiveFormat(name string) string {
+	name = strings.ToLower(name)
+
+	for _, s := range archiveSuffixes {
+		if strings.HasSuffix(name, s.suffix) {
+			return s.format
+		}
+	}
+
+	return formatRaw
+}
+
+// decompress wraps r with decompressor of the given format
+func decompress(r io.Reader, format string) (io.Reader, error) {
+	switch format {
+	case formatTarGzip, formatGzip:
+		return gzip.NewReader(r)
+	case formatTarXz, formatXz:
+		return xz.NewReader(r)
+	case formatTarBzip2:
+		return bzip2.NewReader(r), nil
+	}
+
+	return r, nil
+}
+
+// walkTar calls fn for each regular file in tar archive streamed from r
+func walkTar(r io.Reader, format string, fn func(e *archiveEntry, r io.Reader) error) error {
+	dr, err := decompress(r, format)
+	if err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(dr)
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := fn(&archiveEntry{Name: h.Name, Size: h.Size, Mode: h.FileInfo().Mode()}, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// walkZip calls fn for each regular file in zip archive
+// File content is not read from r unless fn reads it
+func walkZip(r io.ReaderAt, size int64, fn func(e *archiveEntry, r io.Reader) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+
+		zf := &zipFileReader{f: f}
+
+		err := fn(&archiveEntry{Name: f.Name, Size: int64(f.UncompressedSize64), Mode: f.Mode()}, zf)
+		zf.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// zipFileReader opens file in zip archive on the first read
+type zipFileReader struct {
+	f  *zip.File
+	rc io.ReadCloser
+}
+
+func (r *zipFileReader) Read(p []byte) (int, error) {
+	if r.rc == nil {
+		rc, err := r.f.Open()
+		if err != nil {
+			return 0, err
+		}
+		r.rc = rc
+	}
+
+	return r.rc.Read(p)
+}
+
+func (r *zipFileReader) Close() error {
+	if r.rc == nil {
+		return nil
+	}
+
+	return r.rc.Close()
+}
+
+// extractArchive extracts regular files in the archive into dir and returns them
+// Single compressed or plain file is stored as name without the compression suffix
+func extractArchive(filename, name, dir string) ([]*archiveEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []*archiveEntry{}
+
+	extract := func(e *archiveEntry, r io.Reader) error {
+		p, err := archiveEntryPath(dir, e.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+
+		out, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, e.Mode.Perm()|0600)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return err
+		}
+
+		if err := out.Close(); err != nil {
+			return err
+		}
+
+		entries = append(entries, e)
+
+		return nil
+	}
+
+	switch format := detectArchiveFormat(name); format {
+	case formatTar, formatTarGzip, formatTarXz, formatTarBzip2:
+		err = walkTar(f, format, extract)
+	case formatZip:
+		var fi os.FileInfo
+
+		if fi, err = f.Stat(); err == nil {
+			err = walkZip(f, fi.Size(), extract)
+		}
+	default:
+		var r io.Reader
+
+		base := name
+		if format != formatRaw {
+			base = strings.TrimSuffix(name, path.Ext(name))
+		}
+
+		// plain file has no permission information, assume executable
+		if r, err = decompress(f, format); err == nil {
+			err = extract(&archiveEntry{Name: base, Mode: 0755}, r)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// archiveEntryPath returns path to extract the entry into, rejecting path escaping dir
+func archiveEntryPath(dir, name string) (string, error) {
+	p := filepath.Join(dir, filepath.FromSlash(name))
+
+	if !strings.HasPrefix(p, filepath.Clean(dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("Invalid file path in archive: %s", name)
+	}
+
+	return p, nil
+}
+
+// walkRemoteArchive calls fn for each regular file in the asset archive without downloading it into file
+// tar archive is streamed, and zip archive is read partially by HTTP range requests
+func walkRemoteArchive(ctx context.Context, client github.ClientInterface, owner, repo string, asset *github.Asset, fn func(e *archiveEntry, r io.Reader) error) error {
+	var err error
+
+	switch format := detectArchiveFormat(asset.Name); format {
+	case formatTar, formatTarGzip, formatTarXz, formatTarBzip2:
+		var rc io.ReadCloser
+
+		rc, err = client.OpenReleaseAsset(ctx, owner, repo, asset, 0, 0)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		err = walkTar(rc, format, fn)
+	case formatZip:
+		size := int64(asset.Size)
+		err = walkZip(&assetReaderAt{ctx: ctx, client: client, owner: owner, repo: repo, asset: asset, size: size}, size, fn)
+	default:
+		return fmt.Errorf("Unsupported archive format: %s (must be tar, tar.gz, tar.xz, tar.bz2 or zip)", asset.Name)
+	}
+
+	if err == errStopWalk {
+		return nil
+	}
+
+	return err
+}
+
+// assetReaderAt reads release asset by HTTP range requests
+// The last fetched chunk is kept to serve small sequential reads
+type assetReaderAt struct {
+	ctx    context.Context
+	client github.ClientInterface
+	owner  string
+	repo   string
+	asset  *github.Asset
+	size   int64
+
+	buf    []byte
+	bufOff int64
+}
+
+func (r *assetReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n := 0
+
+	for n < len(p) && off+int64(n) < r.size {
+		pos := off + int64(n)
+
+		if pos < r.bufOff || pos >= r.bufOff+int64(len(r.buf)) {
+			if err := r.fetch(pos, len(p)-n); err != nil {
+				return n, err
+			}
+
+			if pos < r.bufOff || pos >= r.bufOff+int64(len(r.buf)) {
+				return n, io.ErrUnexpectedEOF
+			}
+		}
+
+		n += copy(p[n:], r.buf[pos-r.bufOff:])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// fetch reads at least length bytes from off into buffer
+func (r *assetReaderAt) fetch(off int64, length int) error {
+	if length < remoteReadChunkSize {
+		length = remoteReadChunkSize
+	}
+
+	end := off + int64(length)
+	if end > r.size {
+		end = r.size
+	}
+
+	rc, err := r.client.OpenReleaseAsset(r.ctx, r.owner, r.repo, r.asset, off, end-off)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+
+	r.buf, r.bufOff = b, off
+
+	return nil
+}
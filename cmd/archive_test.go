@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ulikunitz/xz"
+)
+
+type testArchiveFile struct {
+	Name string
+	Body string
+	Mode int64
+}
+
+// newTestArchive builds archive of the format in memory
+func newTestArchive(t *testing.T, format string, files []testArchiveFile) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	switch format {
+	case formatZip:
+		zw := zip.NewWriter(buf)
+
+		for _, f := range files {
+			h := &zip.FileHeader{Name: f.Name, Method: zip.Deflate}
+			h.SetMode(os.FileMode(f.Mode))
+
+			w, err := zw.CreateHeader(h)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := w.Write([]byte(f.Body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	case formatTar, formatTarGzip, formatTarXz:
+		tarBuf := &bytes.Buffer{}
+		tw := tar.NewWriter(tarBuf)
+
+		// directory entry is skipped
+		if err := tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range files {
+			if err := tw.WriteHeader(&tar.Header{Name: f.Name, Mode: f.Mode, Size: int64(len(f.Body)), Typeflag: tar.TypeReg}); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := tw.Write([]byte(f.Body)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		switch format {
+		case formatTar:
+			buf = tarBuf
+		case formatTarGzip:
+			zw := gzip.NewWriter(buf)
+			zw.Write(tarBuf.Bytes())
+			zw.Close()
+		case formatTarXz:
+			xw, err := xz.NewWriter(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			xw.Write(tarBuf.Bytes())
+			xw.Close()
+		}
+	case formatGzip:
+		zw := gzip.NewWriter(buf)
+		zw.Write([]byte(files[0].Body))
+		zw.Close()
+	default:
+		buf.WriteString(files[0].Body)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDetectArchiveFormat(t *testing.T) {
+	testcases := map[string]string{
+		"tool_1.0.0_linux_amd64.tar.gz": formatTarGzip,
+		"tool-linux-amd64.TGZ":          formatTarGzip,
+		"tool-x86_64.tar.xz":            formatTarXz,
+		"tool_windows_amd64.zip":        formatZip,
+		"tool-linux-amd64.gz":           formatGzip,
+		"tool-linux-amd64":              formatRaw,
+		"tool.exe":                      formatRaw,
+	}
+
+	for name, want := range testcases {
+		if got := detectArchiveFormat(name); got != want {
+			t.Errorf("%s: want: %q, got: %q", name, want, got)
+		}
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	files := []testArchiveFile{
+		{Name: "tool_1.0.0/tool", Body: "binary", Mode: 0755},
+		{Name: "tool_1.0.0/LICENSE", Body: "MIT", Mode: 0644},
+	}
+
+	testcases := []struct {
+		name  string
+		files []testArchiveFile
+		want  []*archiveEntry
+	}{
+		{
+			name:  "tool.tar.gz",
+			files: files,
+			want: []*archiveEntry{
+				{Name: "tool_1.0.0/tool", Size: 6, Mode: 0755},
+				{Name: "tool_1.0.0/LICENSE", Size: 3, Mode: 0644},
+			},
+		},
+		{
+			name:  "tool.tar.xz",
+			files: files,
+			want: []*archiveEntry{
+				{Name: "tool_1.0.0/tool", Size: 6, Mode: 0755},
+				{Name: "tool_1.0.0/LICENSE", Size: 3, Mode: 0644},
+			},
+		},
+		{
+			name:  "tool.zip",
+			files: files,
+			want: []*archiveEntry{
+				{Name: "tool_1.0.0/tool", Size: 6, Mode: 0755},
+				{Name: "tool_1.0.0/LICENSE", Size: 3, Mode: 0644},
+			},
+		},
+		{
+			name:  "tool-linux-amd64.gz",
+			files: []testArchiveFile{{Body: "binary"}},
+			want: []*archiveEntry{
+				{Name: "tool-linux-amd64", Mode: 0755},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		dir := t.TempDir()
+		filename := filepath.Join(dir, tc.name)
+
+		if err := ioutil.WriteFile(filename, newTestArchive(t, detectArchiveFormat(tc.name), tc.files), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		outDir := filepath.Join(dir, "out")
+
+		got, err := extractArchive(filename, tc.name, outDir)
+		if err != nil {
+			t.Errorf("%s: want: no error, got: %s", tc.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: want: %+v, got: %+v", tc.name, tc.want, got)
+		}
+
+		b, err := ioutil.ReadFile(filepath.Join(outDir, filepath.FromSlash(tc.want[0].Name)))
+		if err != nil {
+			t.Errorf("%s: want: extracted file, got: %s", tc.name, err)
+			continue
+		}
+
+		if string(b) != "binary" {
+			t.Errorf("%s: content want: binary, got: %s", tc.name, string(b))
+		}
+	}
+}
+
+func TestExtractArchive_invalidPath(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "evil.tar.gz")
+
+	if err := ioutil.WriteFile(filename, newTestArchive(t, formatTarGzip, []testArchiveFile{{Name: "../evil", Body: "evil", Mode: 0755}}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := extractArchive(filename, "evil.tar.gz", filepath.Join(dir, "out"))
+	if err == nil {
+		t.Fatal("want: error, got: nil")
+	}
+
+	want := "Invalid file path in archive: ../evil"
+
+	if err.Error() != want {
+		t.Errorf("want: %q, got: %q", want, err.Error())
+	}
+}
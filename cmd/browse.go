@@ -0,0 +1,673 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/dtan4/ghrls/markdown"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// browseCmd represents the browse command
+var browseCmd = &cobra.Command{
+	Use:   "browse REPOSITORY",
+	Short: "Browse releases interactively",
+	Long: `Browse releases interactively
+
+Type to filter tags by fuzzy matching. Detail of the highlighted release is shown in the preview pane.
+
+Keybindings:
+
+  Up/Down, Ctrl-P/Ctrl-N    Move cursor
+  PgUp/PgDn                 Move cursor by page
+  Enter, Ctrl-O             Open the release or tag in web browser
+  Ctrl-Y                    Copy the commit SHA to clipboard
+  Ctrl-D                    Download assets of the release to current directory
+  Ctrl-U                    Clear filter
+  Esc, Ctrl-C               Quit
+`,
+	Annotations: map[string]string{annotationRecordRepository: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+			return fmt.Errorf("browse command requires terminal")
+		}
+
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunBrowse(os.Stdin, os.Stdout, args, client, tf)
+	},
+}
+
+type keyKind int
+
+const (
+	keyRune keyKind = iota
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyEnter
+	keyBackspace
+	keyEsc
+	keyCtrlC
+	keyCtrlD
+	keyCtrlO
+	keyCtrlU
+	keyCtrlY
+)
+
+type key struct {
+	kind keyKind
+	r    rune
+}
+
+type browseAction int
+
+const (
+	actionNone browseAction = iota
+	actionQuit
+	actionOpen
+	actionCopy
+	actionDownload
+)
+
+type describeResult struct {
+	name string
+	tag  *github.Tag
+	err  error
+}
+
+// browseModel holds state of the release browser
+type browseModel struct {
+	owner string
+	repo  string
+	tags  []*github.Tag
+	tf    *TimeFormatter
+
+	query   string
+	matches []int // indices of tags matching query, in display order
+	cursor  int   // position in matches
+	offset  int   // first visible position in matches
+
+	details map[string]*github.Tag
+	errors  map[string]error
+	loading map[string]bool
+	status  string
+}
+
+func newBrowseModel(owner, repo string, tags []*github.Tag, tf *TimeFormatter) *browseModel {
+	m := &browseModel{
+		owner:   owner,
+		repo:    repo,
+		tags:    tags,
+		tf:      tf,
+		details: map[string]*github.Tag{},
+		errors:  map[string]error{},
+		loading: map[string]bool{},
+	}
+	m.filter()
+
+	return m
+}
+
+// RunBrowse runs full-screen release browser of the given repository
+func RunBrowse(in, out *os.File, args []string, client github.ClientInterface, tf *TimeFormatter) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tags, err := client.ListTagsAndReleases(ctx, owner, repo, nil)
+	if err != nil {
+		return err
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(in.Fd()), state)
+
+	// use alternate screen and hide cursor
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan []byte)
+
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 256)
+
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			keys <- b
+		}
+	}()
+
+	results := make(chan describeResult)
+	statuses := make(chan string)
+
+	m := newBrowseModel(owner, repo, tags, tf)
+
+	for {
+		if t := m.selected(); t != nil && m.needsDetail(t) {
+			m.loading[t.Name] = true
+
+			go func(name string) {
+				t, err := client.DescribeRelease(ctx, owner, repo, name)
+
+				select {
+				case results <- describeResult{name: name, tag: t, err: err}:
+				case <-ctx.Done():
+				}
+			}(t.Name)
+		}
+
+		width, height, err := term.GetSize(int(out.Fd()))
+		if err != nil {
+			return err
+		}
+
+		var b strings.Builder
+		b.WriteString("\x1b[H")
+
+		for i, line := range m.render(width, height) {
+			if i > 0 {
+				b.WriteString("\r\n")
+			}
+
+			b.WriteString(line + "\x1b[K")
+		}
+
+		fmt.Fprint(out, b.String())
+
+		select {
+		case bs, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			for _, k := range decodeKeys(bs) {
+				switch m.handleKey(k, height) {
+				case actionQuit:
+					return nil
+				case actionOpen:
+					if err := openURL(m.selectedURL()); err != nil {
+						m.status = err.Error()
+					}
+				case actionCopy:
+					if sha := m.selectedCommit(); sha != "" {
+						// OSC 52 sets system clipboard, which works over SSH too
+						fmt.Fprintf(out, "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(sha)))
+						m.status = "Copied " + sha
+					} else {
+						m.status = "Commit SHA is not available yet"
+					}
+				case actionDownload:
+					assets := m.selectedAssets()
+					if len(assets) == 0 {
+						m.status = "No assets to download"
+						continue
+					}
+
+					m.status = fmt.Sprintf("Downloading %d assets...", len(assets))
+
+					go func() {
+						s := downloadAssets(ctx, client, owner, repo, assets, ".")
+
+						select {
+						case statuses <- s:
+						case <-ctx.Done():
+						}
+					}()
+				}
+			}
+		case r := <-results:
+			delete(m.loading, r.name)
+
+			if r.err != nil {
+				m.errors[r.name] = r.err
+			} else {
+				m.details[r.name] = r.tag
+			}
+		case s := <-statuses:
+			m.status = s
+		}
+	}
+}
+
+// handleKey updates state by the given key and returns action to be performed
+func (m *browseModel) handleKey(k key, height int) browseAction {
+	page := m.listHeight(height)
+
+	switch k.kind {
+	case keyEsc, keyCtrlC:
+		return actionQuit
+	case keyUp:
+		m.move(-1, page)
+	case keyDown:
+		m.move(1, page)
+	case keyPageUp:
+		m.move(-page, page)
+	case keyPageDown:
+		m.move(page, page)
+	case keyEnter, keyCtrlO:
+		if m.selected() != nil {
+			return actionOpen
+		}
+	case keyCtrlY:
+		if m.selected() != nil {
+			return actionCopy
+		}
+	case keyCtrlD:
+		if m.selected() != nil {
+			return actionDownload
+		}
+	case keyBackspace:
+		if m.query != "" {
+			_, size := utf8.DecodeLastRuneInString(m.query)
+			m.query = m.query[:len(m.query)-size]
+			m.filter()
+		}
+	case keyCtrlU:
+		m.query = ""
+		m.filter()
+	case keyRune:
+		m.query += string(k.r)
+		m.filter()
+	}
+
+	return actionNone
+}
+
+// filter rebuilds matches from query, best match first
+func (m *browseModel) filter() {
+	type scored struct {
+		index int
+		score int
+	}
+
+	ss := []scored{}
+
+	for i, t := range m.tags {
+		if score, ok := fuzzyScore(m.query, t.Name); ok {
+			ss = append(ss, scored{index: i, score: score})
+		}
+	}
+
+	sort.SliceStable(ss, func(i, j int) bool {
+		return ss[i].score > ss[j].score
+	})
+
+	m.matches = make([]int, len(ss))
+	for i, s := range ss {
+		m.matches[i] = s.index
+	}
+
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *browseModel) move(delta, page int) {
+	m.cursor += delta
+
+	if m.cursor >= len(m.matches) {
+		m.cursor = len(m.matches) - 1
+	}
+
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	if page > 0 && m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+}
+
+func (m *browseModel) selected() *github.Tag {
+	if len(m.matches) == 0 {
+		return nil
+	}
+
+	return m.tags[m.matches[m.cursor]]
+}
+
+func (m *browseModel) needsDetail(t *github.Tag) bool {
+	if t.Release == nil {
+		return false
+	}
+
+	_, cached := m.details[t.Name]
+	_, failed := m.errors[t.Name]
+
+	return !cached && !failed && !m.loading[t.Name]
+}
+
+func (m *browseModel) selectedURL() string {
+	t := m.selected()
+
+	if d, ok := m.details[t.Name]; ok && d.Release.URL != "" {
+		return d.Release.URL
+	}
+
+	return tagURL(m.owner, m.repo, t)
+}
+
+func (m *browseModel) selectedCommit() string {
+	t := m.selected()
+
+	if d, ok := m.details[t.Name]; ok && d.Release.Commit != "" {
+		return d.Release.Commit
+	}
+
+	return t.Commit
+}
+
+func (m *browseModel) selectedAssets() []*github.Asset {
+	if d, ok := m.details[m.selected().Name]; ok {
+		return d.Release.Assets
+	}
+
+	return []*github.Asset{}
+}
+
+func (m *browseModel) listHeight(height int) int {
+	// prompt and status lines
+	if h := height - 2; h > 0 {
+		return h
+	}
+
+	return 1
+}
+
+// render returns screen lines of the given size
+func (m *browseModel) render(width, height int) []string {
+	listWidth := width * 2 / 5
+	if listWidth < 16 {
+		listWidth = 16
+	}
+
+	previewWidth := width - listWidth - 3
+	if previewWidth < 0 {
+		previewWidth = 0
+	}
+
+	lines := []string{}
+
+	count := fmt.Sprintf("%d/%d", len(m.matches), len(m.tags))
+	lines = append(lines, fit("> "+m.query, width-len(count)-1)+" "+count)
+
+	preview := strings.Split(m.preview(), "\n")
+	listHeight := m.listHeight(height)
+
+	for i := 0; i < listHeight; i++ {
+		var left string
+
+		if pos := m.offset + i; pos < len(m.matches) {
+			t := m.tags[m.matches[pos]]
+
+			typ := "TAG"
+			if t.Release != nil {
+				typ = "REL"
+			}
+
+			left = fit("  "+t.Name, listWidth-4) + " " + typ
+
+			if pos == m.cursor {
+				left = "\x1b[7m" + left + "\x1b[0m"
+			}
+		} else {
+			left = strings.Repeat(" ", listWidth)
+		}
+
+		var right string
+		if i < len(preview) {
+			right = fit(strings.ReplaceAll(preview[i], "\t", "    "), previewWidth)
+		}
+
+		lines = append(lines, strings.TrimRight(left+" │ "+right, " "))
+	}
+
+	footer := m.status
+	if footer == "" {
+		footer = "enter: open  ^y: copy SHA  ^d: download assets  ^u: clear  esc: quit"
+	}
+
+	lines = append(lines, strings.TrimRight(fit(footer, width), " "))
+
+	return lines
+}
+
+// preview returns detail of the selected tag
+func (m *browseModel) preview() string {
+	t := m.selected()
+	if t == nil {
+		return ""
+	}
+
+	if t.Release == nil {
+		return fmt.Sprintf("Tag:    %s\nCommit: %s\n\n(no release)", t.Name, t.Commit)
+	}
+
+	if err, ok := m.errors[t.Name]; ok {
+		return fmt.Sprintf("Failed to load %s: %s", t.Name, err)
+	}
+
+	d, ok := m.details[t.Name]
+	if !ok {
+		return "Loading..."
+	}
+
+	var buf bytes.Buffer
+	printRelease(&buf, d, m.tf, &markdown.Renderer{})
+
+	return buf.String()
+}
+
+// fuzzyScore reports whether all characters in pattern appear in s in order, case-insensitively
+// Higher score means better match: consecutive characters and characters at word boundaries are preferred
+func fuzzyScore(pattern, s string) (int, bool) {
+	if pattern == "" {
+		return 0, true
+	}
+
+	ps := []rune(strings.ToLower(pattern))
+	rs := []rune(strings.ToLower(s))
+
+	score, pi, prev := 0, 0, -2
+
+	for i, r := range rs {
+		if pi == len(ps) {
+			break
+		}
+
+		if r != ps[pi] {
+			continue
+		}
+
+		score++
+
+		if i == prev+1 {
+			score += 4
+		}
+
+		if i == 0 || !unicode.IsLetter(rs[i-1]) && !unicode.IsDigit(rs[i-1]) {
+			score += 2
+		}
+
+		prev = i
+		pi++
+	}
+
+	if pi < len(ps) {
+		return 0, false
+	}
+
+	return score, true
+}
+
+// decodeKeys decodes terminal input into keys
+func decodeKeys(b []byte) []key {
+	keys := []key{}
+
+	for i := 0; i < len(b); {
+		c := b[i]
+
+		if c == 0x1b {
+			if i+2 < len(b) && (b[i+1] == '[' || b[i+1] == 'O') {
+				switch b[i+2] {
+				case 'A':
+					keys = append(keys, key{kind: keyUp})
+				case 'B':
+					keys = append(keys, key{kind: keyDown})
+				case '5', '6':
+					if i+3 < len(b) && b[i+3] == '~' {
+						if b[i+2] == '5' {
+							keys = append(keys, key{kind: keyPageUp})
+						} else {
+							keys = append(keys, key{kind: keyPageDown})
+						}
+						i++
+					}
+				}
+
+				i += 3
+
+				continue
+			}
+
+			keys = append(keys, key{kind: keyEsc})
+			i++
+
+			continue
+		}
+
+		switch c {
+		case 0x03:
+			keys = append(keys, key{kind: keyCtrlC})
+		case 0x04:
+			keys = append(keys, key{kind: keyCtrlD})
+		case 0x0e:
+			keys = append(keys, key{kind: keyDown})
+		case 0x0f:
+			keys = append(keys, key{kind: keyCtrlO})
+		case 0x10:
+			keys = append(keys, key{kind: keyUp})
+		case 0x15:
+			keys = append(keys, key{kind: keyCtrlU})
+		case 0x19:
+			keys = append(keys, key{kind: keyCtrlY})
+		case '\r', '\n':
+			keys = append(keys, key{kind: keyEnter})
+		case 0x7f, 0x08:
+			keys = append(keys, key{kind: keyBackspace})
+		default:
+			r, size := utf8.DecodeRune(b[i:])
+			if unicode.IsPrint(r) {
+				keys = append(keys, key{kind: keyRune, r: r})
+			}
+
+			i += size
+
+			continue
+		}
+
+		i++
+	}
+
+	return keys
+}
+
+// fit truncates or pads s to the given width
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	rs := []rune(s)
+
+	if len(rs) > width {
+		return string(rs[:width-1]) + "…"
+	}
+
+	return s + strings.Repeat(" ", width-len(rs))
+}
+
+// downloadAssets downloads assets into dir and returns summary message
+func downloadAssets(ctx context.Context, client github.ClientInterface, owner, repo string, assets []*github.Asset, dir string) string {
+	for _, a := range assets {
+		if err := downloadAsset(ctx, client, owner, repo, a, filepath.Join(dir, a.Name)); err != nil {
+			return err.Error()
+		}
+	}
+
+	return fmt.Sprintf("Downloaded %d assets to %s", len(assets), dir)
+}
+
+// downloadAsset downloads the asset through GitHub API into filename, so that assets of private repositories can be downloaded too
+// An existing file is never overwritten, and filename is created only after the whole content is downloaded
+func downloadAsset(ctx context.Context, client github.ClientInterface, owner, repo string, a *github.Asset, filename string) error {
+	if _, err := os.Lstat(filename); err == nil {
+		return fmt.Errorf("%s already exists.", filename)
+	}
+
+	rc, err := client.OpenReleaseAsset(ctx, owner, repo, a, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	f, err := createPendingFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, rc); err != nil {
+		f.Abort()
+		return err
+	}
+
+	// filename can be created while downloading
+	return f.CommitNew()
+}
+
+func init() {
+	RootCmd.AddCommand(browseCmd)
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func newTestBrowseModel() *browseModel {
+	tags := []*github.Tag{
+		&github.Tag{Name: "v1.6.0-alpha.0", Commit: "aaaa"},
+		&github.Tag{Name: "v1.5.2", Commit: "bbbb", Release: &github.Release{Name: "v1.5.2"}},
+		&github.Tag{Name: "v1.5.2-beta.0", Commit: "cccc"},
+		&github.Tag{Name: "v1.5.1", Commit: "dddd", Release: &github.Release{Name: "v1.5.1"}},
+	}
+
+	return newBrowseModel("owner", "repo", tags, NewTimeFormatter(time.UTC, ""))
+}
+
+func TestFuzzyScore(t *testing.T) {
+	testcases := []struct {
+		pattern string
+		s       string
+		ok      bool
+	}{
+		{pattern: "", s: "v1.5.2", ok: true},
+		{pattern: "152", s: "v1.5.2", ok: true},
+		{pattern: "V15B", s: "v1.5.2-beta.0", ok: true},
+		{pattern: "251", s: "v1.5.2", ok: false},
+		{pattern: "alpha", s: "v1.5.2", ok: false},
+	}
+
+	for _, tc := range testcases {
+		if _, ok := fuzzyScore(tc.pattern, tc.s); ok != tc.ok {
+			t.Errorf("fuzzyScore(%q, %q) want: %t, got: %t", tc.pattern, tc.s, tc.ok, ok)
+		}
+	}
+
+	// consecutive match at word boundary is preferred
+	exact, _ := fuzzyScore("beta", "v1.5.2-beta.0")
+	scattered, _ := fuzzyScore("beta", "v1.5.0-b.e.t.a")
+	if exact <= scattered {
+		t.Errorf("want: %d > %d", exact, scattered)
+	}
+}
+
+func TestDecodeKeys(t *testing.T) {
+	testcases := []struct {
+		input string
+		want  []key
+	}{
+		{
+			input: "v1é",
+			want:  []key{{kind: keyRune, r: 'v'}, {kind: keyRune, r: '1'}, {kind: keyRune, r: 'é'}},
+		},
+		{
+			input: "\x1b[A\x1b[B\x1bOA\x1b[5~\x1b[6~",
+			want:  []key{{kind: keyUp}, {kind: keyDown}, {kind: keyUp}, {kind: keyPageUp}, {kind: keyPageDown}},
+		},
+		{
+			input: "\x1b",
+			want:  []key{{kind: keyEsc}},
+		},
+		{
+			input: "\r\x7f\x03\x04\x0e\x0f\x10\x15\x19",
+			want: []key{
+				{kind: keyEnter}, {kind: keyBackspace}, {kind: keyCtrlC}, {kind: keyCtrlD}, {kind: keyDown},
+				{kind: keyCtrlO}, {kind: keyUp}, {kind: keyCtrlU}, {kind: keyCtrlY},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := decodeKeys([]byte(tc.input)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("input %q want: %#v, got: %#v", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestBrowseModel_handleKey(t *testing.T) {
+	m := newTestBrowseModel()
+
+	// list height is 2
+	height := 4
+
+	m.handleKey(key{kind: keyDown}, height)
+	m.handleKey(key{kind: keyDown}, height)
+
+	if got := m.selected().Name; got != "v1.5.2-beta.0" {
+		t.Errorf("selected want: %q, got: %q", "v1.5.2-beta.0", got)
+	}
+
+	if m.offset != 1 {
+		t.Errorf("offset want: 1, got: %d", m.offset)
+	}
+
+	m.handleKey(key{kind: keyPageDown}, height)
+
+	if got := m.selected().Name; got != "v1.5.1" {
+		t.Errorf("selected want: %q, got: %q", "v1.5.1", got)
+	}
+
+	for _, r := range "152" {
+		m.handleKey(key{kind: keyRune, r: r}, height)
+	}
+
+	if m.query != "152" {
+		t.Errorf("query want: %q, got: %q", "152", m.query)
+	}
+
+	if len(m.matches) != 2 || m.selected().Name != "v1.5.2" {
+		t.Errorf("want: v1.5.2 selected out of 2 matches, got: %q out of %d", m.selected().Name, len(m.matches))
+	}
+
+	m.handleKey(key{kind: keyBackspace}, height)
+
+	if m.query != "15" {
+		t.Errorf("query want: %q, got: %q", "15", m.query)
+	}
+
+	if action := m.handleKey(key{kind: keyCtrlY}, height); action != actionCopy {
+		t.Errorf("action want: %d, got: %d", actionCopy, action)
+	}
+
+	if action := m.handleKey(key{kind: keyEsc}, height); action != actionQuit {
+		t.Errorf("action want: %d, got: %d", actionQuit, action)
+	}
+
+	m.handleKey(key{kind: keyCtrlU}, height)
+
+	if m.query != "" || len(m.matches) != 4 {
+		t.Errorf("want: filter cleared, got: query %q, %d matches", m.query, len(m.matches))
+	}
+}
+
+func TestBrowseModel_selected(t *testing.T) {
+	m := newTestBrowseModel()
+	m.move(1, 10)
+
+	if !m.needsDetail(m.selected()) {
+		t.Error("want: release detail to be loaded")
+	}
+
+	if got, want := m.selectedURL(), "https://github.com/owner/repo/releases/tag/v1.5.2"; got != want {
+		t.Errorf("url want: %q, got: %q", want, got)
+	}
+
+	if got, want := m.selectedCommit(), "bbbb"; got != want {
+		t.Errorf("commit want: %q, got: %q", want, got)
+	}
+
+	m.details["v1.5.2"] = &github.Tag{
+		Name: "v1.5.2",
+		Release: &github.Release{
+			Assets: []*github.Asset{{Name: "linux.tar.gz", URL: "https://github.com/owner/repo/releases/download/v1.5.2/linux.tar.gz"}},
+			Commit: "08e099554f3c31f6e6f07b448ab3ed78d0520507",
+			URL:    "https://github.com/owner/repo/releases/tag/v1.5.2",
+		},
+	}
+
+	if m.needsDetail(m.selected()) {
+		t.Error("want: cached release detail to be reused")
+	}
+
+	if got, want := m.selectedCommit(), "08e099554f3c31f6e6f07b448ab3ed78d0520507"; got != want {
+		t.Errorf("commit want: %q, got: %q", want, got)
+	}
+
+	if got := m.selectedAssets(); len(got) != 1 {
+		t.Errorf("want: 1 asset, got: %d", len(got))
+	}
+
+	m.move(-1, 10)
+
+	if m.needsDetail(m.selected()) {
+		t.Error("want: plain tag not to be described")
+	}
+
+	if got, want := m.selectedURL(), "https://github.com/owner/repo/tree/v1.6.0-alpha.0"; got != want {
+		t.Errorf("url want: %q, got: %q", want, got)
+	}
+}
+
+func TestBrowseModel_render(t *testing.T) {
+	m := newTestBrowseModel()
+
+	got := m.render(60, 5)
+
+	want := []string{
+		"> " + strings.Repeat(" ", 54) + " 4/4",
+		"\x1b[7m  v1.6.0-alpha.0     TAG\x1b[0m │ Tag:    v1.6.0-alpha.0",
+		"  v1.5.2             REL │ Commit: aaaa",
+		"  v1.5.2-beta.0      TAG │",
+		"enter: open  ^y: copy SHA  ^d: download assets  ^u: clear  …",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want:\n%q\ngot:\n%q", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	m.move(1, 3)
+
+	if got := m.render(60, 5)[1]; !strings.HasSuffix(got, "│ Loading...") {
+		t.Errorf("want: loading preview, got: %q", got)
+	}
+}
+
+func TestDownloadAsset(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			// shorter body than Content-Length aborts the copy
+			w.Header().Set("Content-Length", "100")
+			fmt.Fprint(w, "partial")
+			return
+		}
+
+		fmt.Fprint(w, "content")
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "ghrls-download-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	client := fakeClient{}
+	asset := &github.Asset{Name: "asset", URL: ts.URL + "/asset"}
+	filename := filepath.Join(dir, "asset")
+
+	if err := downloadAsset(ctx, client, "owner", "repo", asset, filename); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if b, _ := ioutil.ReadFile(filename); string(b) != "content" {
+		t.Errorf("content want: %q, got: %q", "content", string(b))
+	}
+
+	if err := downloadAsset(ctx, client, "owner", "repo", asset, filename); err == nil || !strings.HasSuffix(err.Error(), "already exists.") {
+		t.Errorf("want: already exists error, got: %v", err)
+	}
+
+	broken := &github.Asset{Name: "broken", URL: ts.URL + "/broken"}
+
+	if err := downloadAsset(ctx, client, "owner", "repo", broken, filepath.Join(dir, "broken")); err == nil {
+		t.Error("want: error, got: nil")
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("failed download must not leave file, got: %d files", len(entries))
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maximum number of recently used repositories to remember
+	maxRecentRepositories = 50
+	// tag list is refetched if cache is older than this
+	tagCacheTTL = time.Hour
+
+	recentRepositoriesFile = "repositories"
+	tagsCacheDir           = "tags"
+)
+
+type tagCache struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Tags      []string  `json:"tags"`
+}
+
+// cacheDir returns directory to store cache files
+// GHRLS_CACHE_DIR overrides the default location
+func cacheDir() (string, error) {
+	if dir := os.Getenv("GHRLS_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "ghrls"), nil
+}
+
+// dataDir returns directory to store data which must survive cache cleanup, e.g. download history
+// GHRLS_DATA_DIR overrides the default location ($XDG_DATA_HOME/ghrls or ~/.local/share/ghrls)
+func dataDir() (string, error) {
+	if dir := os.Getenv("GHRLS_DATA_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "ghrls"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "share", "ghrls"), nil
+}
+
+// loadRecentRepositories returns recently used repositories, most recent first
+func loadRecentRepositories() []string {
+	dir, err := cacheDir()
+	if err != nil {
+		return []string{}
+	}
+
+	f, err := os.Open(filepath.Join(dir, recentRepositoriesFile))
+	if err != nil {
+		return []string{}
+	}
+	defer f.Close()
+
+	repos := []string{}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			repos = append(repos, line)
+		}
+	}
+
+	return repos
+}
+
+// annotationRecordRepository marks command whose first argument is repository to be remembered for shell completion
+const annotationRecordRepository = "ghrls/record-repository"
+
+// recordRepositoryHook remembers repository of the succeeded command marked with annotationRecordRepository
+// Failure only warns, since remembering repository is not a part of the command
+func recordRepositoryHook(cmd *cobra.Command, args []string) {
+	if _, ok := cmd.Annotations[annotationRecordRepository]; !ok || len(args) == 0 {
+		return
+	}
+
+	if err := recordRepository(args[0]); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to remember %s for shell completion: %s\n", args[0], err)
+	}
+}
+
+// recordRepository remembers the given repository as the most recently used one
+func recordRepository(name string) error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	repos := []string{name}
+
+	for _, r := range loadRecentRepositories() {
+		if r != name && len(repos) < maxRecentRepositories {
+			repos = append(repos, r)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(dir, recentRepositoriesFile), []byte(strings.Join(repos, "\n")+"\n"))
+}
+
+// loadCachedTags returns cached tag names of the given repository
+// false is returned if cache does not exist or is older than ttl
+func loadCachedTags(owner, repo string, ttl time.Duration) ([]string, bool) {
+	if !isSafePathElement(owner) || !isSafePathElement(repo) {
+		return nil, false
+	}
+
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, false
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, tagsCacheDir, owner, repo+".json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var c tagCache
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, false
+	}
+
+	if time.Since(c.UpdatedAt) > ttl {
+		return nil, false
+	}
+
+	return c.Tags, true
+}
+
+// saveCachedTags stores tag names of the given repository
+func saveCachedTags(owner, repo string, tags []string) error {
+	if !isSafePathElement(owner) || !isSafePathElement(repo) {
+		return fmt.Errorf("Invalid repository name: %s/%s", owner, repo)
+	}
+
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	dir = filepath.Join(dir, tagsCacheDir, owner)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(tagCache{
+		UpdatedAt: time.Now(),
+		Tags:      tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filepath.Join(dir, repo+".json"), b)
+}
+
+// isSafePathElement reports whether s can be used as file name without escaping parent directory
+func isSafePathElement(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
+
+// writeFileAtomic writes data to temporary file and renames it, so that readers never see partial content
+func writeFileAtomic(filename string, data []byte) error {
+	f, err := createPendingFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Abort()
+		return err
+	}
+
+	return f.Commit()
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func setCacheDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "ghrls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	setenv(t, "GHRLS_CACHE_DIR", &dir)
+
+	return dir
+}
+
+func TestRecordRepository(t *testing.T) {
+	setCacheDir(t)
+
+	for _, r := range []string{"owner/a", "owner/b", "other/c", "owner/a"} {
+		if err := recordRepository(r); err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+	}
+
+	want := []string{"owner/a", "other/c", "owner/b"}
+
+	if got := loadRecentRepositories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
+
+func TestRecordRepositoryHook(t *testing.T) {
+	dir := setCacheDir(t)
+
+	marked := &cobra.Command{Annotations: map[string]string{annotationRecordRepository: ""}}
+	unmarked := &cobra.Command{}
+
+	recordRepositoryHook(unmarked, []string{"owner/a"})
+	recordRepositoryHook(marked, []string{"owner/b"})
+
+	want := []string{"owner/b"}
+
+	if got := loadRecentRepositories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	// cache directory cannot be created under regular file
+	blocker := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(blocker, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, "GHRLS_CACHE_DIR", &blocker)
+
+	stderr := new(bytes.Buffer)
+	marked.SetErr(stderr)
+
+	recordRepositoryHook(marked, []string{"owner/c"})
+
+	if got := stderr.String(); !strings.HasPrefix(got, "Warning: failed to remember owner/c for shell completion: ") {
+		t.Errorf("want: warning, got: %q", got)
+	}
+}
+
+func TestCachedTags(t *testing.T) {
+	dir := setCacheDir(t)
+
+	if _, ok := loadCachedTags("owner", "repo", time.Hour); ok {
+		t.Error("want: cache miss")
+	}
+
+	want := []string{"v1.1.0", "v1.0.0"}
+
+	if err := saveCachedTags("owner", "repo", want); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	got, ok := loadCachedTags("owner", "repo", time.Hour)
+	if !ok {
+		t.Fatal("want: cache hit")
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	if _, ok := loadCachedTags("owner", "repo", 0); ok {
+		t.Error("want: expired cache to be ignored")
+	}
+
+	if err := saveCachedTags("..", "repo", want); err == nil {
+		t.Error("want: error, got: nil")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "repo.json")); !os.IsNotExist(err) {
+		t.Error("want: cache file not to be written outside of cache directory")
+	}
+}
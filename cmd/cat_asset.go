@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// catAssetCmd represents the cat-asset command
+var catAssetCmd = &cobra.Command{
+	Use:   "cat-asset REPOSITORY TAG [ASSET] PATH",
+	Short: "Print file in archive asset without downloading it",
+	Long: `Print file in archive asset without downloading it
+
+PATH is the path of file in the archive printed by "ghrls ls-asset".
+tar archive is streamed until the file is found, and only the file index and the file are read from zip archive.
+ASSET can be omitted with --os, --arch or --auto.
+
+Example:
+
+$ ghrls cat-asset dtan4/ghrls v1.1.0 ghrls_1.1.0_linux_amd64.tar.gz LICENSE
+The MIT License (MIT)
+...
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		platform, err := newPlatform(&catAssetOpts.platformOpts)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunCatAsset(os.Stdout, os.Stderr, args, client, platform)
+	},
+}
+
+var catAssetOpts = struct {
+	platformOpts
+}{}
+
+func RunCatAsset(stdout, stderr io.Writer, args []string, client github.ClientInterface, platform *github.Platform) error {
+	if len(args) != 4 && (platform == nil || len(args) != 3) {
+		return fmt.Errorf("Please specify repository <user/name>, tag, asset name and file path.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag, name, filePath := args[1], "", args[len(args)-1]
+	if len(args) == 4 {
+		name = args[2]
+	}
+
+	ctx := context.Background()
+
+	asset, err := findReleaseAsset(ctx, client, owner, repo, tag, name, platform)
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	err = walkRemoteArchive(ctx, client, owner, repo, asset, func(e *archiveEntry, r io.Reader) error {
+		if strings.TrimPrefix(e.Name, "./") != strings.TrimPrefix(filePath, "./") {
+			return nil
+		}
+
+		found = true
+
+		if _, err := io.Copy(stdout, r); err != nil {
+			return err
+		}
+
+		return errStopWalk
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s not found in %s", filePath, asset.Name)
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(catAssetCmd)
+
+	addPlatformFlags(catAssetCmd.Flags(), &catAssetOpts.platformOpts)
+}
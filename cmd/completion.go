@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maximum time to wait for GitHub API on completion
+	completionTimeout = 5 * time.Second
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate shell completion script",
+	Long: `Generate shell completion script
+
+Repositories are completed from recently used ones, and tags are completed from the cached tag list of the repository.
+
+Example:
+
+# bash
+$ source <(ghrls completion bash)
+
+# zsh
+$ ghrls completion zsh > "${fpath[1]}/_ghrls"
+
+# fish
+$ ghrls completion fish > ~/.config/fish/completions/ghrls.fish
+
+# PowerShell
+PS> ghrls completion powershell | Out-String | Invoke-Expression
+`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      cobra.ExactValidArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return RootCmd.GenBashCompletion(os.Stdout)
+		case "zsh":
+			return RootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return RootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return RootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+
+		return fmt.Errorf("Unsupported shell: %s", args[0])
+	},
+}
+
+// completeRepositoryArg completes the first argument with recently used repositories
+func completeRepositoryArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completeRepository(loadRecentRepositories(), toComplete)
+}
+
+// completeRepositoryAndTagArgs completes repository and then tag of the repository
+func completeRepositoryAndTagArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completeRepository(loadRecentRepositories(), toComplete)
+	case 1:
+		initConfig()
+
+		client, err := newClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return completeTag(client, args[0], toComplete)
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepositoryTagAndFileArgs completes repository, tag and then local files
+func completeRepositoryTagAndFileArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) < 2 {
+		return completeRepositoryAndTagArgs(cmd, args, toComplete)
+	}
+
+	return nil, cobra.ShellCompDirectiveDefault
+}
+
+// completeInstalledRepositoryArgs completes repositories whose binaries are installed by ghrls
+func completeInstalledRepositoryArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tools, err := loadInstalledTools()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	candidates := []string{}
+
+	for _, t := range tools {
+		if strings.HasPrefix(t.Repository, toComplete) {
+			candidates = append(candidates, t.Repository)
+		}
+	}
+
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRepository returns owners ("owner/") until owner is typed, then repositories of the owner
+func completeRepository(repos []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	candidates := []string{}
+	seen := map[string]bool{}
+
+	if !strings.Contains(toComplete, "/") {
+		for _, r := range repos {
+			owner := strings.SplitN(r, "/", 2)[0] + "/"
+
+			if strings.HasPrefix(owner, toComplete) && !seen[owner] {
+				candidates = append(candidates, owner)
+				seen[owner] = true
+			}
+		}
+
+		return candidates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	for _, r := range repos {
+		if strings.HasPrefix(r, toComplete) {
+			candidates = append(candidates, r)
+		}
+	}
+
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTag returns tags of the repository, fetching tag list if it is not cached
+func completeTag(client github.ClientInterface, repository, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ss := strings.Split(repository, "/")
+	if len(ss) != 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	owner, repo := ss[0], ss[1]
+
+	tags, ok := loadCachedTags(owner, repo, tagCacheTTL)
+	if !ok {
+		ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+		defer cancel()
+
+		ts, err := client.ListTagsAndReleases(ctx, owner, repo, nil)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		tags = []string{}
+		for _, t := range ts {
+			tags = append(tags, t.Name)
+		}
+
+		saveCachedTags(owner, repo, tags)
+	}
+
+	candidates := []string{}
+
+	for _, t := range tags {
+		if strings.HasPrefix(t, toComplete) {
+			candidates = append(candidates, t)
+		}
+	}
+
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	RootCmd.AddCommand(completionCmd)
+
+	getCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	listCmd.ValidArgsFunction = completeRepositoryArg
+	browseCmd.ValidArgsFunction = completeRepositoryArg
+	editCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	uploadCmd.ValidArgsFunction = completeRepositoryTagAndFileArgs
+	deleteAssetCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	pruneCmd.ValidArgsFunction = completeRepositoryArg
+	notesCmd.ValidArgsFunction = completeRepositoryArg
+	nextVersionCmd.ValidArgsFunction = completeRepositoryArg
+	exportCmd.ValidArgsFunction = completeRepositoryArg
+	statsCmd.ValidArgsFunction = completeRepositoryArg
+	downloadsCmd.ValidArgsFunction = completeRepositoryArg
+	downloadCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	installCmd.ValidArgsFunction = completeRepositoryArg
+	upgradeCmd.ValidArgsFunction = completeInstalledRepositoryArgs
+	lsAssetCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	catAssetCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	sourceCmd.ValidArgsFunction = completeRepositoryAndTagArgs
+	mirrorCmd.ValidArgsFunction = completeRepositoryArg
+	syncCmd.ValidArgsFunction = completeRepositoryArg
+	latestCmd.ValidArgsFunction = completeRepositoryArg
+}
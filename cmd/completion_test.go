@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+type fakeClientForCompletion struct {
+	fakeClientForList
+
+	calls *int
+}
+
+func (c fakeClientForCompletion) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *github.ListFilter) ([]*github.Tag, error) {
+	*c.calls++
+
+	return c.fakeClientForList.ListTagsAndReleases(ctx, owner, repo, filter)
+}
+
+func TestCompleteRepository(t *testing.T) {
+	repos := []string{"kubernetes/kubernetes", "dtan4/ghrls", "kubernetes/kops", "dtan4/k8stail"}
+
+	testcases := []struct {
+		toComplete string
+		want       []string
+		directive  cobra.ShellCompDirective
+	}{
+		{
+			toComplete: "",
+			want:       []string{"kubernetes/", "dtan4/"},
+			directive:  cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+		},
+		{
+			toComplete: "dt",
+			want:       []string{"dtan4/"},
+			directive:  cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+		},
+		{
+			toComplete: "kubernetes/k",
+			want:       []string{"kubernetes/kubernetes", "kubernetes/kops"},
+			directive:  cobra.ShellCompDirectiveNoFileComp,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, directive := completeRepository(repos, tc.toComplete)
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q want: %q, got: %q", tc.toComplete, tc.want, got)
+		}
+
+		if directive != tc.directive {
+			t.Errorf("%q directive want: %d, got: %d", tc.toComplete, tc.directive, directive)
+		}
+	}
+}
+
+func TestCompleteTag(t *testing.T) {
+	setCacheDir(t)
+
+	calls := 0
+	client := fakeClientForCompletion{
+		fakeClientForList: fakeClientForList{
+			Tags: []*github.Tag{
+				&github.Tag{Name: "v1.5.2"},
+				&github.Tag{Name: "v1.5.0-beta.3"},
+				&github.Tag{Name: "v1.4.0"},
+			},
+		},
+		calls: &calls,
+	}
+
+	for i := 0; i < 2; i++ {
+		got, _ := completeTag(client, "owner/repo", "v1.5")
+
+		if want := []string{"v1.5.2", "v1.5.0-beta.3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("want: %q, got: %q", want, got)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("want: tag list fetched once and cached, got: %d calls", calls)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// createCmd represents the create command
+var createCmd = &cobra.Command{
+	Use:   "create REPOSITORY TAG",
+	Short: "Create release",
+	Long: `Create release
+
+The tag is created from --target (or the default branch) if it does not exist yet.
+Release notes are read from --notes-file; "-" reads them from stdin.
+
+Example:
+
+$ ghrls create dtan4/ghrls v1.0.0 --title v1.0.0 --notes-file CHANGELOG.md --draft
+https://github.com/dtan4/ghrls/releases/tag/untagged-0123456789abcdef0123
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := newReleaseParams(cmd.Flags(), &createOpts, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunCreate(os.Stdout, os.Stderr, args, client, params)
+	},
+}
+
+// releaseOpts represents flags of create and edit command
+type releaseOpts struct {
+	Draft      bool
+	NotesFile  string
+	Prerelease bool
+	Target     string
+	Title      string
+}
+
+var createOpts = releaseOpts{}
+
+func RunCreate(stdout, stderr io.Writer, args []string, client github.ClientInterface, params *github.ReleaseParams) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	ctx := context.Background()
+
+	t, err := client.CreateRelease(ctx, owner, repo, tag, params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, tagURL(owner, repo, t))
+
+	return nil
+}
+
+// newReleaseParams builds ReleaseParams from flags
+// Only flags given explicitly are set, so that edit leaves the other attributes unchanged
+func newReleaseParams(flags *pflag.FlagSet, opts *releaseOpts, stdin io.Reader) (*github.ReleaseParams, error) {
+	params := &github.ReleaseParams{}
+
+	if flags.Changed("title") {
+		params.Name = &opts.Title
+	}
+
+	if flags.Changed("notes-file") {
+		body, err := readNotesFile(opts.NotesFile, stdin)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Body = &body
+	}
+
+	if flags.Changed("draft") {
+		params.Draft = &opts.Draft
+	}
+
+	if flags.Changed("prerelease") {
+		params.Prerelease = &opts.Prerelease
+	}
+
+	if flags.Changed("target") {
+		params.Target = &opts.Target
+	}
+
+	return params, nil
+}
+
+// readNotesFile reads release notes from the given file, or stdin if filename is "-"
+func readNotesFile(filename string, stdin io.Reader) (string, error) {
+	var b []byte
+	var err error
+
+	if filename == "-" {
+		b, err = ioutil.ReadAll(stdin)
+	} else {
+		b, err = ioutil.ReadFile(filename)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read release notes: %w", err)
+	}
+
+	return string(b), nil
+}
+
+// addReleaseFlags registers flags shared by create and edit command
+func addReleaseFlags(flags *pflag.FlagSet, opts *releaseOpts) {
+	flags.StringVarP(&opts.Title, "title", "t", "", "Release title (default: tag name)")
+	flags.StringVarP(&opts.NotesFile, "notes-file", "F", "", `Read release notes from file ("-" to read from stdin)`)
+	flags.BoolVar(&opts.Draft, "draft", false, "Save the release as draft")
+	flags.BoolVar(&opts.Prerelease, "prerelease", false, "Mark the release as prerelease")
+	flags.StringVar(&opts.Target, "target", "", "Commit SHA or branch the tag is created from if it does not exist (default: default branch)")
+}
+
+func init() {
+	RootCmd.AddCommand(createCmd)
+
+	addReleaseFlags(createCmd.Flags(), &createOpts)
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/pflag"
+)
+
+type fakeClientForRelease struct {
+	fakeClient
+
+	Tag    *github.Tag
+	Err    error
+	params **github.ReleaseParams
+}
+
+func (c fakeClientForRelease) CreateRelease(ctx context.Context, owner, repo, tag string, params *github.ReleaseParams) (*github.Tag, error) {
+	*c.params = params
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	return c.Tag, nil
+}
+
+func (c fakeClientForRelease) EditRelease(ctx context.Context, owner, repo, tag string, params *github.ReleaseParams) (*github.Tag, error) {
+	*c.params = params
+
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	return c.Tag, nil
+}
+
+func TestRunCreate_success(t *testing.T) {
+	var got *github.ReleaseParams
+
+	client := fakeClientForRelease{
+		Tag: &github.Tag{
+			Name: "v1.0.0",
+			Release: &github.Release{
+				URL: "https://github.com/dtan4/ghrls/releases/tag/untagged-0123",
+			},
+		},
+		params: &got,
+	}
+
+	params := &github.ReleaseParams{
+		Name:  stringPtr("v1.0.0"),
+		Draft: boolPtr(true),
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunCreate(stdout, stderr, []string{"dtan4/ghrls", "v1.0.0"}, client, params); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if got != params {
+		t.Errorf("params want: %#v, got: %#v", params, got)
+	}
+
+	want := "https://github.com/dtan4/ghrls/releases/tag/untagged-0123\n"
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunCreate_error(t *testing.T) {
+	var got *github.ReleaseParams
+
+	testcases := []struct {
+		args   []string
+		client github.ClientInterface
+		want   string
+	}{
+		{
+			args:   []string{"dtan4/ghrls"},
+			client: fakeClientForRelease{params: &got},
+			want:   "Please specify repository <user/name> and tag.",
+		},
+		{
+			args:   []string{"ghrls", "v1.0.0"},
+			client: fakeClientForRelease{params: &got},
+			want:   "Invalid repository name: ghrls",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0"},
+			client: fakeClientForRelease{Err: fmt.Errorf("422 Validation Failed"), params: &got},
+			want:   "422 Validation Failed",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunCreate(stdout, stderr, tc.args, tc.client, &github.ReleaseParams{})
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestNewReleaseParams(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.md")
+
+	if err := ioutil.WriteFile(notes, []byte("from file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		args  []string
+		stdin string
+		want  *github.ReleaseParams
+	}{
+		{
+			args: []string{},
+			want: &github.ReleaseParams{},
+		},
+		{
+			args: []string{"--title", "v1.0.0", "--draft", "--prerelease=false", "--target", "856abeb"},
+			want: &github.ReleaseParams{
+				Draft:      boolPtr(true),
+				Name:       stringPtr("v1.0.0"),
+				Prerelease: boolPtr(false),
+				Target:     stringPtr("856abeb"),
+			},
+		},
+		{
+			args: []string{"--notes-file", notes},
+			want: &github.ReleaseParams{
+				Body: stringPtr("from file"),
+			},
+		},
+		{
+			args:  []string{"-F", "-", "--draft=false"},
+			stdin: "from stdin",
+			want: &github.ReleaseParams{
+				Body:  stringPtr("from stdin"),
+				Draft: boolPtr(false),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		opts := releaseOpts{}
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		addReleaseFlags(flags, &opts)
+
+		if err := flags.Parse(tc.args); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := newReleaseParams(flags, &opts, strings.NewReader(tc.stdin))
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("args %q: want: %s, got: %s", tc.args, dumpReleaseParams(tc.want), dumpReleaseParams(got))
+		}
+	}
+}
+
+func TestNewReleaseParams_noFile(t *testing.T) {
+	opts := releaseOpts{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	addReleaseFlags(flags, &opts)
+
+	if err := flags.Parse([]string{"--notes-file", filepath.Join(t.TempDir(), "missing.md")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newReleaseParams(flags, &opts, strings.NewReader("")); err == nil {
+		t.Errorf("want: error, got: nil")
+	}
+}
+
+func dumpReleaseParams(p *github.ReleaseParams) string {
+	s := func(v *string) string {
+		if v == nil {
+			return "<nil>"
+		}
+		return *v
+	}
+	b := func(v *bool) string {
+		if v == nil {
+			return "<nil>"
+		}
+		return fmt.Sprint(*v)
+	}
+
+	return fmt.Sprintf("{Body:%s Draft:%s Name:%s Prerelease:%s Target:%s}", s(p.Body), b(p.Draft), s(p.Name), b(p.Prerelease), s(p.Target))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
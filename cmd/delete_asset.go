@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// deleteAssetCmd represents the delete-asset command
+var deleteAssetCmd = &cobra.Command{
+	Use:   "delete-asset REPOSITORY TAG NAME",
+	Short: "Delete asset from release",
+	Long: `Delete asset from release
+
+Example:
+
+$ ghrls delete-asset dtan4/ghrls v1.0.0 ghrls_linux_amd64.tar.gz
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunDeleteAsset(os.Stdout, os.Stderr, args, client)
+	},
+}
+
+func RunDeleteAsset(stdout, stderr io.Writer, args []string, client github.ClientInterface) error {
+	if len(args) != 3 {
+		return fmt.Errorf("Please specify repository <user/name>, tag and asset name.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag, name := args[1], args[2]
+
+	ctx := context.Background()
+
+	if err := client.DeleteReleaseAsset(ctx, owner, repo, tag, name); err != nil {
+		if errors.Is(err, github.ErrAssetNotFound) {
+			return fmt.Errorf("%s/%s@%s : asset %s not found", owner, repo, tag, name)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(deleteAssetCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func TestRunDeleteAsset(t *testing.T) {
+	testcases := []struct {
+		args   []string
+		client github.ClientInterface
+		want   string
+	}{
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", "ghrls.tar.gz"},
+			client: fakeClientForAsset{},
+			want:   "",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0"},
+			client: fakeClientForAsset{},
+			want:   "Please specify repository <user/name>, tag and asset name.",
+		},
+		{
+			args:   []string{"ghrls", "v1.0.0", "ghrls.tar.gz"},
+			client: fakeClientForAsset{},
+			want:   "Invalid repository name: ghrls",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", "ghrls.zip"},
+			client: fakeClientForAsset{Err: fmt.Errorf("ghrls.zip: %w", github.ErrAssetNotFound)},
+			want:   "dtan4/ghrls@v1.0.0 : asset ghrls.zip not found",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v9.9.9", "ghrls.tar.gz"},
+			client: fakeClientForAsset{Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "dtan4", Repo: "ghrls", Tag: "v9.9.9"}},
+			want:   "dtan4/ghrls@v9.9.9: release not found",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunDeleteAsset(stdout, stderr, tc.args, tc.client)
+
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+
+		if got != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
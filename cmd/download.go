@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// downloadCmd represents the download command
+var downloadCmd = &cobra.Command{
+	Use:   "download REPOSITORY TAG [PATTERN...]",
+	Short: "Download release assets",
+	Long: `Download release assets
+
+Assets are selected by glob PATTERN, e.g. "*.tar.gz", and/or by platform with --os, --arch or --auto.
+Platform is detected from asset names such as "tool_1.2.3_linux_amd64.tar.gz", "tool-darwin-arm64.zip" and
+"tool-x86_64-unknown-linux-musl.tar.gz". If several assets are equally suitable, the candidates are listed;
+narrow them down with PATTERN.
+
+Example:
+
+$ ghrls download dtan4/ghrls v1.0.0 --auto
+ghrls_1.0.0_linux_amd64.tar.gz
+
+$ ghrls download dtan4/ghrls v1.0.0 '*.txt' --dir /tmp
+/tmp/checksums.txt
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		platform, err := newPlatform(&downloadOpts.platformOpts)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunDownload(os.Stdout, os.Stderr, args, client, platform, downloadOpts.Dir)
+	},
+}
+
+var downloadOpts = struct {
+	platformOpts
+	Dir string
+}{}
+
+// RunDownload downloads assets matching the patterns, or the one built for the platform if platform is not nil
+// Downloaded file paths are printed
+func RunDownload(stdout, stderr io.Writer, args []string, client github.ClientInterface, platform *github.Platform, dir string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag, patterns := args[1], args[2:]
+
+	if len(patterns) == 0 && platform == nil {
+		return fmt.Errorf("Please specify asset name pattern or --os, --arch or --auto.")
+	}
+
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("Invalid pattern: %s", p)
+		}
+	}
+
+	ctx := context.Background()
+
+	t, err := client.DescribeRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	assets := matchAssets(t.Release.Assets, patterns)
+
+	if platform != nil {
+		a, err := selectPlatformAsset(owner, repo, tag, assets, *platform)
+		if err != nil {
+			return err
+		}
+
+		assets = []*github.Asset{a}
+	}
+
+	if len(assets) == 0 {
+		return fmt.Errorf("%s/%s@%s : no asset matches %s", owner, repo, tag, strings.Join(patterns, " "))
+	}
+
+	for _, a := range assets {
+		filename := filepath.Join(dir, a.Name)
+
+		if err := downloadAsset(ctx, client, owner, repo, a, filename); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(stdout, filename)
+	}
+
+	return nil
+}
+
+// matchAssets returns assets whose name matches any of the glob patterns, or all assets if no pattern is given
+func matchAssets(assets []*github.Asset, patterns []string) []*github.Asset {
+	if len(patterns) == 0 {
+		return assets
+	}
+
+	matched := []*github.Asset{}
+
+	for _, a := range assets {
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, a.Name); ok {
+				matched = append(matched, a)
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+func init() {
+	RootCmd.AddCommand(downloadCmd)
+
+	addPlatformFlags(downloadCmd.Flags(), &downloadOpts.platformOpts)
+	downloadCmd.Flags().StringVarP(&downloadOpts.Dir, "dir", "D", ".", "Directory to save assets")
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func newReleaseWithAssets(names ...string) *github.Tag {
+	assets := []*github.Asset{}
+
+	for _, name := range names {
+		assets = append(assets, &github.Asset{
+			Name: name,
+			URL:  "https://github.com/owner/repo/releases/download/v1.0.0/" + name,
+		})
+	}
+
+	return &github.Tag{
+		Name: "v1.0.0",
+		Release: &github.Release{
+			Assets: assets,
+		},
+	}
+}
+
+// fakeClientForDownload records names of the opened assets
+type fakeClientForDownload struct {
+	fakeClientForGet
+	opened *[]string
+}
+
+func (c fakeClientForDownload) OpenReleaseAsset(ctx context.Context, owner, repo string, asset *github.Asset, offset, length int64) (io.ReadCloser, error) {
+	if owner != "owner" || repo != "repo" {
+		return nil, fmt.Errorf("unexpected repository: %s/%s", owner, repo)
+	}
+
+	*c.opened = append(*c.opened, asset.Name)
+
+	return ioutil.NopCloser(strings.NewReader(asset.Name)), nil
+}
+
+func TestRunDownload_success(t *testing.T) {
+	release := newReleaseWithAssets(
+		"checksums.txt",
+		"tool_1.0.0_darwin_arm64.tar.gz",
+		"tool_1.0.0_linux_amd64.tar.gz",
+		"tool_1.0.0_linux_arm64.tar.gz",
+	)
+
+	testcases := []struct {
+		args     []string
+		platform *github.Platform
+		want     []string
+	}{
+		{
+			args: []string{"owner/repo", "v1.0.0", "*.txt"},
+			want: []string{"checksums.txt"},
+		},
+		{
+			args: []string{"owner/repo", "v1.0.0", "*linux*", "*.txt"},
+			want: []string{"checksums.txt", "tool_1.0.0_linux_amd64.tar.gz", "tool_1.0.0_linux_arm64.tar.gz"},
+		},
+		{
+			args:     []string{"owner/repo", "v1.0.0"},
+			platform: &github.Platform{OS: "linux", Arch: "arm64"},
+			want:     []string{"tool_1.0.0_linux_arm64.tar.gz"},
+		},
+	}
+
+	for _, tc := range testcases {
+		dir := t.TempDir()
+		downloaded := []string{}
+		client := fakeClientForDownload{fakeClientForGet: fakeClientForGet{Tag: release}, opened: &downloaded}
+
+		stdout := &bytes.Buffer{}
+
+		if err := RunDownload(stdout, &bytes.Buffer{}, tc.args, client, tc.platform, dir); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(downloaded, tc.want) {
+			t.Errorf("want: %q, got: %q", tc.want, downloaded)
+		}
+
+		wantStdout := ""
+		for _, name := range tc.want {
+			wantStdout += filepath.Join(dir, name) + "\n"
+		}
+
+		if stdout.String() != wantStdout {
+			t.Errorf("stdout want: %q, got: %q", wantStdout, stdout.String())
+		}
+
+		for _, name := range tc.want {
+			if b, _ := ioutil.ReadFile(filepath.Join(dir, name)); string(b) != name {
+				t.Errorf("content of %s want: %q, got: %q", name, name, string(b))
+			}
+		}
+	}
+}
+
+func TestRunDownload_error(t *testing.T) {
+	release := newReleaseWithAssets(
+		"tool_1.0.0_linux_amd64.tar.gz",
+		"other_1.0.0_linux_amd64.tar.gz",
+	)
+
+	testcases := []struct {
+		args     []string
+		platform *github.Platform
+		client   github.ClientInterface
+		want     string
+	}{
+		{
+			args:   []string{"owner/repo"},
+			client: fakeClientForGet{Tag: release},
+			want:   "Please specify repository <user/name> and tag.",
+		},
+		{
+			args:   []string{"owner/repo", "v1.0.0"},
+			client: fakeClientForGet{Tag: release},
+			want:   "Please specify asset name pattern or --os, --arch or --auto.",
+		},
+		{
+			args:   []string{"owner/repo", "v1.0.0", "[a"},
+			client: fakeClientForGet{Tag: release},
+			want:   "Invalid pattern: [a",
+		},
+		{
+			args:   []string{"owner/repo", "v1.0.0", "*.zip"},
+			client: fakeClientForGet{Tag: release},
+			want:   "owner/repo@v1.0.0 : no asset matches *.zip",
+		},
+		{
+			args:   []string{"owner/repo", "v1.0.0", "*.zip"},
+			client: fakeClientForGet{Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "owner", Repo: "repo", Tag: "v1.0.0"}},
+			want:   "owner/repo@v1.0.0: release not found",
+		},
+		{
+			args:     []string{"owner/repo", "v1.0.0"},
+			platform: &github.Platform{OS: "linux", Arch: "amd64"},
+			client:   fakeClientForGet{Tag: release},
+			want: "Multiple assets of owner/repo@v1.0.0 match linux/amd64. Please specify asset name:\n" +
+				"  tool_1.0.0_linux_amd64.tar.gz\n" +
+				"  other_1.0.0_linux_amd64.tar.gz",
+		},
+		{
+			args:     []string{"owner/repo", "v1.0.0", "tool_*"},
+			platform: &github.Platform{OS: "darwin", Arch: "amd64"},
+			client:   fakeClientForGet{Tag: release},
+			want: "No asset of owner/repo@v1.0.0 matches darwin/amd64. Available assets:\n" +
+				"  tool_1.0.0_linux_amd64.tar.gz",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := RunDownload(&bytes.Buffer{}, &bytes.Buffer{}, tc.args, tc.client, tc.platform, t.TempDir())
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestNewPlatform(t *testing.T) {
+	testcases := []struct {
+		opts    platformOpts
+		want    *github.Platform
+		wantErr bool
+	}{
+		{
+			opts: platformOpts{},
+			want: nil,
+		},
+		{
+			opts: platformOpts{OS: "macos", Arch: "x86_64"},
+			want: &github.Platform{OS: "darwin", Arch: "amd64"},
+		},
+		{
+			opts:    platformOpts{OS: "beos", Arch: "amd64"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := newPlatform(&tc.opts)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("want: error, got: nil")
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("want: %#v, got: %#v", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	downloadsDataDir = "downloads"
+)
+
+// downloadsCmd represents the downloads command
+var downloadsCmd = &cobra.Command{
+	Use:   "downloads REPOSITORY",
+	Short: "Report download counts of release assets",
+	Long: `Report download counts of release assets
+
+GitHub reports only cumulative download counts. --record appends the current counts to local history,
+and --trend shows growth between recorded snapshots without calling GitHub API.
+History is stored in $GHRLS_DATA_DIR, $XDG_DATA_HOME/ghrls or ~/.local/share/ghrls.
+
+Example:
+
+$ ghrls downloads dtan4/ghrls --tag v1.1.0
+TAG       ASSET                       DOWNLOADS
+v1.1.0    ghrls_Linux_x86_64.tar.gz   150
+v1.1.0    ghrls_Darwin_x86_64.tar.gz  80
+
+$ ghrls downloads dtan4/ghrls --asset-pattern '*Linux*' --trend
+TAG       ASSET                       RECORDEDAT                       DOWNLOADS    GROWTH
+v1.1.0    ghrls_Linux_x86_64.tar.gz   2021-03-01 09:00:00 +0900 JST    100
+v1.1.0    ghrls_Linux_x86_64.tar.gz   2021-03-08 09:00:00 +0900 JST    150          +50
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		if _, err := path.Match(downloadsOpts.AssetPattern, ""); err != nil {
+			return fmt.Errorf("Invalid asset pattern: %s", downloadsOpts.AssetPattern)
+		}
+
+		if downloadsOpts.Trend && !downloadsOpts.Record {
+			return RunDownloadsTrend(os.Stdout, os.Stderr, args, tf, downloadsOpts.Tag, downloadsOpts.AssetPattern)
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		out := io.Writer(os.Stdout)
+		if downloadsOpts.Trend {
+			// trend including the new snapshot is printed instead
+			out = io.Discard
+		}
+
+		if err := RunDownloads(out, os.Stderr, args, client, tf, downloadsOpts.Tag, downloadsOpts.AssetPattern, downloadsOpts.Record); err != nil {
+			return err
+		}
+
+		if downloadsOpts.Trend {
+			return RunDownloadsTrend(os.Stdout, os.Stderr, args, tf, downloadsOpts.Tag, downloadsOpts.AssetPattern)
+		}
+
+		return nil
+	},
+}
+
+var downloadsOpts = struct {
+	AssetPattern string
+	Record       bool
+	Tag          string
+	Trend        bool
+}{}
+
+// downloadsSnapshot represents download counts of assets at a point of time
+type downloadsSnapshot struct {
+	RecordedAt time.Time         `json:"recorded_at"`
+	Assets     []downloadsRecord `json:"assets"`
+}
+
+type downloadsRecord struct {
+	Tag           string `json:"tag"`
+	Name          string `json:"name"`
+	DownloadCount int    `json:"download_count"`
+}
+
+// matchDownloadsRecord reports whether the asset is selected by tag and glob pattern
+// Empty tag and pattern select all assets
+func matchDownloadsRecord(r downloadsRecord, tag, pattern string) bool {
+	if tag != "" && r.Tag != tag {
+		return false
+	}
+
+	if pattern == "" {
+		return true
+	}
+
+	ok, _ := path.Match(pattern, r.Name)
+
+	return ok
+}
+
+// downloadsHistoryFile returns file which stores snapshots of the given repository in JSON Lines
+func downloadsHistoryFile(owner, repo string) (string, error) {
+	if !isSafePathElement(owner) || !isSafePathElement(repo) {
+		return "", fmt.Errorf("Invalid repository name: %s/%s", owner, repo)
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, downloadsDataDir, owner, repo+".jsonl"), nil
+}
+
+// appendDownloadsSnapshot appends the snapshot to history of the given repository
+func appendDownloadsSnapshot(owner, repo string, s *downloadsSnapshot) error {
+	filename, err := downloadsHistoryFile(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// loadDownloadsSnapshots returns recorded snapshots of the given repository, oldest first
+// Broken lines, e.g. partially written one, are skipped
+func loadDownloadsSnapshots(owner, repo string) ([]*downloadsSnapshot, error) {
+	filename, err := downloadsHistoryFile(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*downloadsSnapshot{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	snapshots := []*downloadsSnapshot{}
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for sc.Scan() {
+		var s downloadsSnapshot
+
+		if err := json.Unmarshal(sc.Bytes(), &s); err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, &s)
+	}
+
+	return snapshots, sc.Err()
+}
+
+// RunDownloads prints the current download counts of assets, and records them if record is true
+func RunDownloads(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter, tag, pattern string, record bool) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx := context.Background()
+
+	snapshot := &downloadsSnapshot{
+		RecordedAt: tf.now(),
+		Assets:     []downloadsRecord{},
+	}
+
+	add := func(t *github.Tag) error {
+		if t.Release == nil {
+			return nil
+		}
+
+		for _, a := range t.Release.Assets {
+			r := downloadsRecord{Tag: t.Name, Name: a.Name, DownloadCount: a.DownloadCount}
+
+			if matchDownloadsRecord(r, tag, pattern) {
+				snapshot.Assets = append(snapshot.Assets, r)
+			}
+		}
+
+		return nil
+	}
+
+	var err error
+
+	if tag != "" {
+		var t *github.Tag
+
+		t, err = client.DescribeRelease(ctx, owner, repo, tag)
+		if err == nil {
+			err = add(t)
+		}
+	} else {
+		err = client.WalkReleases(ctx, owner, repo, add)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "TAG\tASSET\tDOWNLOADS")
+
+	for _, r := range snapshot.Assets {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", r.Tag, r.Name, r.DownloadCount)
+	}
+
+	w.Flush()
+
+	if !record {
+		return nil
+	}
+
+	if err := appendDownloadsSnapshot(owner, repo, snapshot); err != nil {
+		return fmt.Errorf("failed to record download counts: %w", err)
+	}
+
+	return nil
+}
+
+// RunDownloadsTrend prints recorded download counts of each asset and growth from the previous snapshot
+func RunDownloadsTrend(stdout, stderr io.Writer, args []string, tf *TimeFormatter, tag, pattern string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	snapshots, err := loadDownloadsSnapshots(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		return fmt.Errorf("No download counts recorded for %s/%s. Run with --record first.", owner, repo)
+	}
+
+	type point struct {
+		recordedAt time.Time
+		count      int
+	}
+
+	keys := []downloadsRecord{}
+	points := map[downloadsRecord][]point{}
+
+	for _, s := range snapshots {
+		for _, r := range s.Assets {
+			if !matchDownloadsRecord(r, tag, pattern) {
+				continue
+			}
+
+			key := downloadsRecord{Tag: r.Tag, Name: r.Name}
+
+			if _, ok := points[key]; !ok {
+				keys = append(keys, key)
+			}
+
+			points[key] = append(points[key], point{recordedAt: s.RecordedAt, count: r.DownloadCount})
+		}
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "TAG\tASSET\tRECORDEDAT\tDOWNLOADS\tGROWTH")
+
+	for _, key := range keys {
+		for i, p := range points[key] {
+			growth := ""
+
+			if i > 0 {
+				growth = fmt.Sprintf("%+d", p.count-points[key][i-1].count)
+			}
+
+			fmt.Fprintln(w, strings.Join([]string{key.Tag, key.Name, tf.Format(p.recordedAt), strconv.Itoa(p.count), growth}, "\t"))
+		}
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(downloadsCmd)
+
+	downloadsCmd.Flags().StringVar(&downloadsOpts.Tag, "tag", "", "Report only assets of the tag")
+	downloadsCmd.Flags().StringVar(&downloadsOpts.AssetPattern, "asset-pattern", "", `Report only assets whose name matches the glob pattern (e.g. "*linux*")`)
+	downloadsCmd.Flags().BoolVar(&downloadsOpts.Record, "record", false, "Record the current download counts to local history")
+	downloadsCmd.Flags().BoolVar(&downloadsOpts.Trend, "trend", false, "Show growth of download counts between recorded snapshots")
+}
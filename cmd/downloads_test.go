@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForDownloads struct {
+	fakeClientForExport
+}
+
+func (c fakeClientForDownloads) DescribeRelease(ctx context.Context, owner, repo, tag string) (*github.Tag, error) {
+	for _, t := range c.Releases {
+		if t.Name == tag {
+			return t, nil
+		}
+	}
+
+	return &github.Tag{Name: tag}, nil
+}
+
+func downloadsTestClient(counts ...int) fakeClientForDownloads {
+	asset := func(name string, count int) *github.Asset {
+		return &github.Asset{Name: name, DownloadCount: count}
+	}
+
+	return fakeClientForDownloads{
+		fakeClientForExport: fakeClientForExport{
+			Releases: []*github.Tag{
+				{
+					Name: "v1.1.0",
+					Release: &github.Release{
+						Assets: []*github.Asset{
+							asset("ghrls_linux_amd64.tar.gz", counts[0]),
+							asset("ghrls_darwin_amd64.tar.gz", counts[1]),
+						},
+					},
+				},
+				{
+					Name: "v1.0.0",
+					Release: &github.Release{
+						Assets: []*github.Asset{
+							asset("ghrls_linux_amd64.tar.gz", counts[2]),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// setDataDir points data directory to temporary directory during the test
+func setDataDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "ghrls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	setenv(t, "GHRLS_DATA_DIR", &dir)
+
+	return dir
+}
+
+func TestRunDownloads(t *testing.T) {
+	setDataDir(t)
+
+	testcases := []struct {
+		tag     string
+		pattern string
+		want    string
+	}{
+		{
+			want: `TAG       ASSET                        DOWNLOADS
+v1.1.0    ghrls_linux_amd64.tar.gz     10
+v1.1.0    ghrls_darwin_amd64.tar.gz    5
+v1.0.0    ghrls_linux_amd64.tar.gz     100
+`,
+		},
+		{
+			tag: "v1.1.0",
+			want: `TAG       ASSET                        DOWNLOADS
+v1.1.0    ghrls_linux_amd64.tar.gz     10
+v1.1.0    ghrls_darwin_amd64.tar.gz    5
+`,
+		},
+		{
+			pattern: "*linux*",
+			want: `TAG       ASSET                       DOWNLOADS
+v1.1.0    ghrls_linux_amd64.tar.gz    10
+v1.0.0    ghrls_linux_amd64.tar.gz    100
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		if err := RunDownloads(stdout, stderr, []string{"dtan4/ghrls"}, downloadsTestClient(10, 5, 100), NewTimeFormatter(time.UTC, "default"), tc.tag, tc.pattern, false); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("want:\n%s\ngot:\n%s", tc.want, stdout.String())
+		}
+	}
+
+	// nothing is recorded without record
+	if _, err := os.Stat(filepath.Join(os.Getenv("GHRLS_DATA_DIR"), "downloads")); !os.IsNotExist(err) {
+		t.Errorf("want: no history, got: %v", err)
+	}
+}
+
+func TestRunDownloadsTrend(t *testing.T) {
+	setDataDir(t)
+
+	tf := NewTimeFormatter(time.UTC, "2006-01-02")
+
+	snapshots := []struct {
+		day    int
+		counts []int
+	}{
+		{day: 1, counts: []int{10, 5, 100}},
+		{day: 8, counts: []int{25, 6, 110}},
+		{day: 15, counts: []int{60, 6, 111}},
+	}
+
+	for _, s := range snapshots {
+		tf.now = func() time.Time { return time.Date(2021, 3, s.day, 0, 0, 0, 0, time.UTC) }
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		// only linux assets are recorded
+		if err := RunDownloads(stdout, stderr, []string{"dtan4/ghrls"}, downloadsTestClient(s.counts...), tf, "", "*linux*", true); err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunDownloadsTrend(stdout, stderr, []string{"dtan4/ghrls"}, tf, "v1.1.0", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := `TAG       ASSET                       RECORDEDAT    DOWNLOADS    GROWTH
+v1.1.0    ghrls_linux_amd64.tar.gz    2021-03-01    10           
+v1.1.0    ghrls_linux_amd64.tar.gz    2021-03-08    25           +15
+v1.1.0    ghrls_linux_amd64.tar.gz    2021-03-15    60           +35
+`
+	if stdout.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, stdout.String())
+	}
+}
+
+func TestRunDownloadsTrend_noHistory(t *testing.T) {
+	setDataDir(t)
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	err := RunDownloadsTrend(stdout, stderr, []string{"dtan4/ghrls"}, NewTimeFormatter(time.UTC, "default"), "", "")
+
+	want := "No download counts recorded for dtan4/ghrls. Run with --record first."
+	if err == nil || err.Error() != want {
+		t.Errorf("want: %q, got: %v", want, err)
+	}
+}
+
+func TestLoadDownloadsSnapshots_broken(t *testing.T) {
+	dir := setDataDir(t)
+
+	filename := filepath.Join(dir, "downloads", "dtan4", "ghrls.jsonl")
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `{"recorded_at": "2021-03-01T00:00:00Z", "assets": [{"tag": "v1.0.0", "name": "a", "download_count": 1}]}
+{"recorded_at": "2021-03-08T00:
+`
+	if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loadDownloadsSnapshots("dtan4", "ghrls")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if len(got) != 1 || got[0].Assets[0].DownloadCount != 1 {
+		t.Errorf("want: 1 snapshot, got: %#v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit REPOSITORY TAG",
+	Short: "Edit release",
+	Long: `Edit release
+
+Only the attributes given by flags are updated. Draft release can be edited too.
+
+Example:
+
+# publish draft release
+$ ghrls edit dtan4/ghrls v1.0.0 --draft=false
+https://github.com/dtan4/ghrls/releases/tag/v1.0.0
+
+# update release notes
+$ ghrls edit dtan4/ghrls v1.0.0 --notes-file CHANGELOG.md
+https://github.com/dtan4/ghrls/releases/tag/v1.0.0
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params, err := newReleaseParams(cmd.Flags(), &editOpts, os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunEdit(os.Stdout, os.Stderr, args, client, params)
+	},
+}
+
+var editOpts = releaseOpts{}
+
+func RunEdit(stdout, stderr io.Writer, args []string, client github.ClientInterface, params *github.ReleaseParams) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	if *params == (github.ReleaseParams{}) {
+		return fmt.Errorf("Please specify at least one of --title, --notes-file, --draft, --prerelease and --target.")
+	}
+
+	ctx := context.Background()
+
+	t, err := client.EditRelease(ctx, owner, repo, tag, params)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, tagURL(owner, repo, t))
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(editCmd)
+
+	addReleaseFlags(editCmd.Flags(), &editOpts)
+}
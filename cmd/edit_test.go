@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func TestRunEdit_success(t *testing.T) {
+	var got *github.ReleaseParams
+
+	client := fakeClientForRelease{
+		Tag: &github.Tag{
+			Name: "v1.0.0",
+			Release: &github.Release{
+				URL: "https://github.com/dtan4/ghrls/releases/tag/v1.0.0",
+			},
+		},
+		params: &got,
+	}
+
+	params := &github.ReleaseParams{
+		Draft: boolPtr(false),
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunEdit(stdout, stderr, []string{"dtan4/ghrls", "v1.0.0"}, client, params); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if got != params {
+		t.Errorf("params want: %#v, got: %#v", params, got)
+	}
+
+	want := "https://github.com/dtan4/ghrls/releases/tag/v1.0.0\n"
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunEdit_error(t *testing.T) {
+	var got *github.ReleaseParams
+
+	testcases := []struct {
+		args   []string
+		params *github.ReleaseParams
+		client github.ClientInterface
+		want   string
+	}{
+		{
+			args:   []string{"dtan4/ghrls"},
+			params: &github.ReleaseParams{Draft: boolPtr(false)},
+			client: fakeClientForRelease{params: &got},
+			want:   "Please specify repository <user/name> and tag.",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0"},
+			params: &github.ReleaseParams{},
+			client: fakeClientForRelease{params: &got},
+			want:   "Please specify at least one of --title, --notes-file, --draft, --prerelease and --target.",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v9.9.9"},
+			params: &github.ReleaseParams{Draft: boolPtr(false)},
+			client: fakeClientForRelease{Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "dtan4", Repo: "ghrls", Tag: "v9.9.9"}, params: &got},
+			want:   "dtan4/ghrls@v9.9.9: release not found",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunEdit(stdout, stderr, tc.args, tc.client, tc.params)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export REPOSITORY",
+	Short: "Export tags, releases and assets",
+	Long: `Export tags, releases and assets
+
+--format csv writes one row per asset, or per tag/release without assets.
+--format sqlite writes "tags", "releases" and "assets" tables into the database file.
+Times are written in RFC3339 (UTC) regardless of --time-format.
+Rows are written to stdout as soon as each page is retrieved from GitHub.
+--out file is replaced only after the whole export succeeded.
+
+Example:
+
+$ ghrls export dtan4/ghrls --format csv --out ghrls.csv
+$ ghrls export dtan4/ghrls --format sqlite --out ghrls.db
+$ sqlite3 ghrls.db 'SELECT tag, SUM(download_count) FROM assets GROUP BY tag'
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		var w exportWriter
+
+		switch exportOpts.Format {
+		case "csv":
+			if exportOpts.Out == "" || exportOpts.Out == "-" {
+				w = newCSVExportWriter(os.Stdout)
+				break
+			}
+
+			w, err = newCSVExportFileWriter(exportOpts.Out)
+			if err != nil {
+				return err
+			}
+		case "sqlite":
+			if exportOpts.Out == "" || exportOpts.Out == "-" {
+				return fmt.Errorf("Please specify database file by --out.")
+			}
+
+			w, err = newSQLiteExportWriter(exportOpts.Out)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("Invalid format: %s (must be csv or sqlite)", exportOpts.Format)
+		}
+
+		return RunExport(os.Stdout, os.Stderr, args, client, w)
+	},
+}
+
+var exportOpts = struct {
+	Format string
+	Out    string
+}{}
+
+// exportWriter writes exported tags into tabular form
+type exportWriter interface {
+	// WriteTag writes the tag, its release and assets of the release
+	// Release is nil if the tag has no release, and Commit is empty for draft release whose tag does not exist
+	WriteTag(t *github.Tag) error
+	// Commit flushes written rows and replaces the output file with them
+	Commit() error
+	// Abort discards written rows, keeping the existing output file
+	Abort() error
+}
+
+// RunExport exports all tags, releases and assets of the given repository by w
+// Releases are exported first, then tags without release
+// Written rows are committed only if the whole export succeeded
+func RunExport(stdout, stderr io.Writer, args []string, client github.ClientInterface, w exportWriter) (err error) {
+	defer func() {
+		if err != nil {
+			w.Abort()
+			return
+		}
+
+		err = w.Commit()
+	}()
+
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx := context.Background()
+
+	// only names and commits are held to join tags with releases
+	tagNames := []string{}
+	commits := map[string]string{}
+
+	if err := client.WalkTags(ctx, owner, repo, func(t *github.Tag) error {
+		tagNames = append(tagNames, t.Name)
+		commits[t.Name] = t.Commit
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	released := map[string]bool{}
+
+	if err := client.WalkReleases(ctx, owner, repo, func(t *github.Tag) error {
+		t.Commit = commits[t.Name]
+		released[t.Name] = true
+
+		return w.WriteTag(t)
+	}); err != nil {
+		return err
+	}
+
+	for _, name := range tagNames {
+		if released[name] {
+			continue
+		}
+
+		if err := w.WriteTag(&github.Tag{Name: name, Commit: commits[name]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var (
+	csvExportHeader = []string{
+		"tag",
+		"commit",
+		"release_name",
+		"author",
+		"draft",
+		"prerelease",
+		"created_at",
+		"published_at",
+		"release_url",
+		"asset_name",
+		"content_type",
+		"size",
+		"download_count",
+		"asset_url",
+	}
+)
+
+// csvExportWriter writes one row per asset, or per tag/release without assets
+type csvExportWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+	// file is nil if rows are streamed into writer
+	file *pendingFile
+}
+
+func newCSVExportWriter(w io.Writer) *csvExportWriter {
+	return &csvExportWriter{
+		w: csv.NewWriter(w),
+	}
+}
+
+// newCSVExportFileWriter creates writer which replaces the given file on Commit
+func newCSVExportFileWriter(filename string) (*csvExportWriter, error) {
+	f, err := createPendingFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvExportWriter{
+		w:    csv.NewWriter(f),
+		file: f,
+	}, nil
+}
+
+func (e *csvExportWriter) WriteTag(t *github.Tag) error {
+	if !e.wroteHeader {
+		if err := e.w.Write(csvExportHeader); err != nil {
+			return err
+		}
+
+		e.wroteHeader = true
+	}
+
+	row := []string{t.Name, t.Commit}
+
+	if t.Release == nil {
+		return e.w.Write(append(row, make([]string, len(csvExportHeader)-len(row))...))
+	}
+
+	r := t.Release
+
+	row = append(row,
+		r.Name,
+		r.Author,
+		strconv.FormatBool(r.Draft),
+		strconv.FormatBool(r.Prerelease),
+		formatExportTime(r.CreatedAt),
+		formatExportTime(r.PublishedAt),
+		r.URL,
+	)
+
+	if len(r.Assets) == 0 {
+		return e.w.Write(append(row, "", "", "", "", ""))
+	}
+
+	for _, a := range r.Assets {
+		if err := e.w.Write(append(row[:len(row):len(row)],
+			a.Name,
+			a.ContentType,
+			strconv.Itoa(a.Size),
+			strconv.Itoa(a.DownloadCount),
+			a.URL,
+		)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *csvExportWriter) Commit() error {
+	if !e.wroteHeader {
+		if err := e.w.Write(csvExportHeader); err != nil {
+			e.Abort()
+			return err
+		}
+	}
+
+	e.w.Flush()
+
+	if err := e.w.Error(); err != nil {
+		e.Abort()
+		return err
+	}
+
+	if e.file == nil {
+		return nil
+	}
+
+	return e.file.Commit()
+}
+
+// Abort discards written rows, though rows already streamed into writer cannot be taken back
+func (e *csvExportWriter) Abort() error {
+	if e.file == nil {
+		return nil
+	}
+
+	return e.file.Abort()
+}
+
+// formatExportTime formats time in RFC3339, or empty string for zero time
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportOpts.Format, "format", "csv", "Output format: csv or sqlite")
+	exportCmd.Flags().StringVarP(&exportOpts.Out, "out", "o", "", `Output file ("-" or empty for stdout, csv only)`)
+}
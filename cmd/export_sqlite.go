@@ -0,0 +1,127 @@
+//go:build darwin || (linux && 386) || (linux && amd64) || (linux && arm) || (linux && arm64) || (windows && 386) || (windows && amd64)
+// +build darwin linux,386 linux,amd64 linux,arm linux,arm64 windows,386 windows,amd64
+
+package cmd
+
+import (
+	"database/sql"
+
+	"github.com/dtan4/ghrls/github"
+
+	// pure Go SQLite driver, which works without cgo but only on these platforms
+	_ "modernc.org/sqlite"
+)
+
+var (
+	sqliteExportSchema = []string{
+		`CREATE TABLE tags (name TEXT NOT NULL, commit_sha TEXT NOT NULL)`,
+		`CREATE TABLE releases (tag TEXT NOT NULL, name TEXT NOT NULL, author TEXT NOT NULL, draft INTEGER NOT NULL, prerelease INTEGER NOT NULL, created_at TEXT, published_at TEXT, url TEXT NOT NULL)`,
+		`CREATE TABLE assets (tag TEXT NOT NULL, name TEXT NOT NULL, content_type TEXT NOT NULL, size INTEGER NOT NULL, download_count INTEGER NOT NULL, url TEXT NOT NULL)`,
+		`CREATE INDEX tags_name ON tags (name)`,
+		`CREATE INDEX releases_tag ON releases (tag)`,
+		`CREATE INDEX assets_tag ON assets (tag)`,
+	}
+)
+
+// sqliteExportWriter writes tags, releases and assets into separate tables in one transaction
+type sqliteExportWriter struct {
+	db   *sql.DB
+	tx   *sql.Tx
+	file *pendingFile
+}
+
+// newSQLiteExportWriter creates new database in temporary file, which replaces the given file on Commit
+func newSQLiteExportWriter(filename string) (exportWriter, error) {
+	f, err := createPendingFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite opens the file by itself
+	if err := f.File.Close(); err != nil {
+		f.Abort()
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", f.Name())
+	if err != nil {
+		f.Abort()
+		return nil, err
+	}
+
+	for _, q := range sqliteExportSchema {
+		if _, err := db.Exec(q); err != nil {
+			db.Close()
+			f.Abort()
+			return nil, err
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		db.Close()
+		f.Abort()
+		return nil, err
+	}
+
+	return &sqliteExportWriter{
+		db:   db,
+		tx:   tx,
+		file: f,
+	}, nil
+}
+
+func (e *sqliteExportWriter) WriteTag(t *github.Tag) error {
+	if t.Commit != "" {
+		if _, err := e.tx.Exec(`INSERT INTO tags VALUES (?, ?)`, t.Name, t.Commit); err != nil {
+			return err
+		}
+	}
+
+	if t.Release == nil {
+		return nil
+	}
+
+	r := t.Release
+
+	if _, err := e.tx.Exec(`INSERT INTO releases VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.Name, r.Name, r.Author, r.Draft, r.Prerelease, nullString(formatExportTime(r.CreatedAt)), nullString(formatExportTime(r.PublishedAt)), r.URL); err != nil {
+		return err
+	}
+
+	for _, a := range r.Assets {
+		if _, err := e.tx.Exec(`INSERT INTO assets VALUES (?, ?, ?, ?, ?, ?)`,
+			t.Name, a.Name, a.ContentType, a.Size, a.DownloadCount, a.URL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *sqliteExportWriter) Commit() error {
+	if err := e.tx.Commit(); err != nil {
+		e.db.Close()
+		e.file.Abort()
+		return err
+	}
+
+	if err := e.db.Close(); err != nil {
+		e.file.Abort()
+		return err
+	}
+
+	return e.file.Commit()
+}
+
+func (e *sqliteExportWriter) Abort() error {
+	e.tx.Rollback()
+	e.db.Close()
+
+	return e.file.Abort()
+}
+
+// nullString converts empty string into NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,95 @@
+//go:build darwin || (linux && 386) || (linux && amd64) || (linux && arm) || (linux && arm64) || (windows && 386) || (windows && amd64)
+// +build darwin linux,386 linux,amd64 linux,arm linux,arm64 windows,386 windows,amd64
+
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRunExport_sqlite(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ghrls.db")
+
+	// existing database is replaced
+	for i := 0; i < 2; i++ {
+		w, err := newSQLiteExportWriter(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		if err := RunExport(stdout, stderr, []string{"dtan4/ghrls"}, exportTestClient(), w); err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+	}
+
+	// failed export keeps the existing database
+	w, err := newSQLiteExportWriter(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunExport(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/ghrls"}, fakeClientForExport{Err: fmt.Errorf("unexpected error")}, w); err == nil {
+		t.Fatal("want: error, got: nil")
+	}
+
+	if entries, _ := ioutil.ReadDir(filepath.Dir(filename)); len(entries) != 1 {
+		t.Errorf("want: only database file, got: %d files", len(entries))
+	}
+
+	db, err := sql.Open("sqlite", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	testcases := []struct {
+		query string
+		want  []string
+	}{
+		{
+			query: `SELECT name || ' ' || commit_sha FROM tags ORDER BY name`,
+			want:  []string{"v1.0.0 aaa", "v1.0.1 aab", "v1.1.0 bbb"},
+		},
+		{
+			query: `SELECT tag || ' ' || draft || ' ' || prerelease || ' ' || IFNULL(published_at, 'NULL') FROM releases ORDER BY tag`,
+			want:  []string{"v1.0.0 0 1 2021-03-01T00:00:00Z", "v1.1.0 0 0 2021-03-01T16:00:00Z", "v1.2.0 1 0 NULL"},
+		},
+		{
+			query: `SELECT tag || ' ' || SUM(download_count) FROM assets GROUP BY tag`,
+			want:  []string{"v1.1.0 13"},
+		},
+	}
+
+	for _, tc := range testcases {
+		rows, err := db.Query(tc.query)
+		if err != nil {
+			t.Errorf("%s: %s", tc.query, err)
+			continue
+		}
+
+		got := []string{}
+
+		for rows.Next() {
+			var s string
+			if err := rows.Scan(&s); err != nil {
+				t.Fatal(err)
+			}
+
+			got = append(got, s)
+		}
+
+		rows.Close()
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: want: %q, got: %q", tc.query, tc.want, got)
+		}
+	}
+}
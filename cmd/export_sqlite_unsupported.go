@@ -0,0 +1,16 @@
+//go:build !darwin && !(linux && (386 || amd64 || arm || arm64)) && !(windows && (386 || amd64))
+// +build !darwin
+// +build !linux !386,!amd64,!arm,!arm64
+// +build !windows !386,!amd64
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// newSQLiteExportWriter returns error because SQLite driver does not support this platform
+func newSQLiteExportWriter(filename string) (exportWriter, error) {
+	return nil, fmt.Errorf("SQLite export is not supported on %s/%s.", runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForExport struct {
+	fakeClient
+
+	Tags     []*github.Tag
+	Releases []*github.Tag
+	Err      error
+}
+
+func (c fakeClientForExport) WalkReleases(ctx context.Context, owner, repo string, fn func(*github.Tag) error) error {
+	for _, t := range c.Releases {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c fakeClientForExport) WalkTags(ctx context.Context, owner, repo string, fn func(*github.Tag) error) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	for _, t := range c.Tags {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func exportTestClient() fakeClientForExport {
+	return fakeClientForExport{
+		Tags: []*github.Tag{
+			{Name: "v1.1.0", Commit: "bbb"},
+			{Name: "v1.0.1", Commit: "aab"},
+			{Name: "v1.0.0", Commit: "aaa"},
+		},
+		Releases: []*github.Tag{
+			{
+				Name: "v1.2.0",
+				Release: &github.Release{
+					Assets:    []*github.Asset{},
+					Author:    "dtan4",
+					CreatedAt: time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC),
+					Draft:     true,
+					Name:      "v1.2.0",
+					URL:       "https://github.com/dtan4/ghrls/releases/tag/untagged-1",
+				},
+			},
+			{
+				Name: "v1.1.0",
+				Release: &github.Release{
+					Assets: []*github.Asset{
+						{Name: "ghrls_linux_amd64.tar.gz", ContentType: "application/gzip", Size: 1024, DownloadCount: 10, URL: "https://github.com/dtan4/ghrls/releases/download/v1.1.0/ghrls_linux_amd64.tar.gz"},
+						{Name: "checksums.txt", ContentType: "text/plain", Size: 64, DownloadCount: 3, URL: "https://github.com/dtan4/ghrls/releases/download/v1.1.0/checksums.txt"},
+					},
+					Author:      "dtan4",
+					CreatedAt:   time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC),
+					Name:        "v1.1.0, \"stable\"",
+					PublishedAt: time.Date(2021, 3, 2, 1, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+					URL:         "https://github.com/dtan4/ghrls/releases/tag/v1.1.0",
+				},
+			},
+			{
+				Name: "v1.0.0",
+				Release: &github.Release{
+					Assets:      []*github.Asset{},
+					Author:      "dtan4",
+					CreatedAt:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+					Name:        "v1.0.0",
+					Prerelease:  true,
+					PublishedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+					URL:         "https://github.com/dtan4/ghrls/releases/tag/v1.0.0",
+				},
+			},
+		},
+	}
+}
+
+func TestRunExport_csv(t *testing.T) {
+	var buf bytes.Buffer
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunExport(stdout, stderr, []string{"dtan4/ghrls"}, exportTestClient(), newCSVExportWriter(&buf)); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := `tag,commit,release_name,author,draft,prerelease,created_at,published_at,release_url,asset_name,content_type,size,download_count,asset_url
+v1.2.0,,v1.2.0,dtan4,true,false,2021-03-03T00:00:00Z,,https://github.com/dtan4/ghrls/releases/tag/untagged-1,,,,,
+v1.1.0,bbb,"v1.1.0, ""stable""",dtan4,false,false,2021-03-02T00:00:00Z,2021-03-01T16:00:00Z,https://github.com/dtan4/ghrls/releases/tag/v1.1.0,ghrls_linux_amd64.tar.gz,application/gzip,1024,10,https://github.com/dtan4/ghrls/releases/download/v1.1.0/ghrls_linux_amd64.tar.gz
+v1.1.0,bbb,"v1.1.0, ""stable""",dtan4,false,false,2021-03-02T00:00:00Z,2021-03-01T16:00:00Z,https://github.com/dtan4/ghrls/releases/tag/v1.1.0,checksums.txt,text/plain,64,3,https://github.com/dtan4/ghrls/releases/download/v1.1.0/checksums.txt
+v1.0.0,aaa,v1.0.0,dtan4,false,true,2021-03-01T00:00:00Z,2021-03-01T00:00:00Z,https://github.com/dtan4/ghrls/releases/tag/v1.0.0,,,,,
+v1.0.1,aab,,,,,,,,,,,,
+`
+	if buf.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestRunExport_error(t *testing.T) {
+	testcases := []struct {
+		args   []string
+		client github.ClientInterface
+		want   string
+	}{
+		{
+			args:   []string{},
+			client: exportTestClient(),
+			want:   "Please specify repository <user/name>.",
+		},
+		{
+			args:   []string{"ghrls"},
+			client: exportTestClient(),
+			want:   "Invalid repository name: ghrls",
+		},
+		{
+			args:   []string{"dtan4/ghrls"},
+			client: fakeClientForExport{Err: &github.Error{Kind: github.ErrRepoNotFound, Owner: "dtan4", Repo: "ghrls"}},
+			want:   "dtan4/ghrls: repository not found (set GITHUB_TOKEN for private repos)",
+		},
+	}
+
+	for _, tc := range testcases {
+		var buf bytes.Buffer
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunExport(stdout, stderr, tc.args, tc.client, newCSVExportWriter(&buf))
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestRunExport_csvFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ghrls.csv")
+
+	if err := ioutil.WriteFile(filename, []byte("previous export\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// failed export keeps the existing file
+	for _, args := range [][]string{{"ghrls"}, {"dtan4/ghrls"}} {
+		w, err := newCSVExportFileWriter(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := RunExport(&bytes.Buffer{}, &bytes.Buffer{}, args, fakeClientForExport{Err: fmt.Errorf("unexpected error")}, w); err == nil {
+			t.Fatal("want: error, got: nil")
+		}
+
+		if b, _ := ioutil.ReadFile(filename); string(b) != "previous export\n" {
+			t.Errorf("want: existing file kept, got: %q", string(b))
+		}
+	}
+
+	w, err := newCSVExportFileWriter(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RunExport(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/ghrls"}, exportTestClient(), w); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if b, _ := ioutil.ReadFile(filename); !strings.HasPrefix(string(b), "tag,commit,") {
+		t.Errorf("want: existing file replaced, got: %q", string(b))
+	}
+
+	if entries, _ := ioutil.ReadDir(filepath.Dir(filename)); len(entries) != 1 {
+		t.Errorf("want: only exported file, got: %d files", len(entries))
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+// fakeClient implements github.ClientInterface with no-op methods
+// Fakes for each command embed this and override the methods they need
+type fakeClient struct{}
+
+func (c fakeClient) CreateRelease(ctx context.Context, owner, repo, tag string, params *github.ReleaseParams) (*github.Tag, error) {
+	return &github.Tag{}, nil
+}
+
+func (c fakeClient) DeleteRelease(ctx context.Context, owner, repo, tag string, deleteTag bool) error {
+	return nil
+}
+
+func (c fakeClient) DeleteReleaseAsset(ctx context.Context, owner, repo, tag, name string) error {
+	return nil
+}
+
+func (c fakeClient) DescribeRelease(ctx context.Context, owner, repo, tag string) (*github.Tag, error) {
+	return &github.Tag{}, nil
+}
+
+func (c fakeClient) EditRelease(ctx context.Context, owner, repo, tag string, params *github.ReleaseParams) (*github.Tag, error) {
+	return &github.Tag{}, nil
+}
+
+func (c fakeClient) GenerateReleaseNotes(ctx context.Context, owner, repo, tag, previousTag string) (string, error) {
+	return "", nil
+}
+
+func (c fakeClient) LatestRelease(ctx context.Context, owner, repo string) (*github.Tag, error) {
+	return nil, nil
+}
+
+func (c fakeClient) ListCommits(ctx context.Context, owner, repo, from, to string) ([]*github.Commit, error) {
+	return []*github.Commit{}, nil
+}
+
+func (c fakeClient) ListMergedPullRequests(ctx context.Context, owner, repo, from, to string) ([]*github.PullRequest, error) {
+	return []*github.PullRequest{}, nil
+}
+
+func (c fakeClient) ListRepositories(ctx context.Context, owner string, filter *github.RepositoryFilter) ([]*github.Repository, error) {
+	return []*github.Repository{}, nil
+}
+
+func (c fakeClient) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *github.ListFilter) ([]*github.Tag, error) {
+	return []*github.Tag{}, nil
+}
+
+// OpenReleaseAsset fetches asset.URL, which points to test server standing in for GitHub API
+func (c fakeClient) OpenReleaseAsset(ctx context.Context, owner, repo string, asset *github.Asset, offset, length int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", asset.Name, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+func (c fakeClient) OpenSourceArchive(ctx context.Context, owner, repo, tag string, format github.ArchiveFormat) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader("")), nil
+}
+
+func (c fakeClient) UploadReleaseAsset(ctx context.Context, owner, repo, tag, filename string, clobber bool) (*github.Asset, error) {
+	return &github.Asset{}, nil
+}
+
+func (c fakeClient) WalkReleases(ctx context.Context, owner, repo string, fn func(*github.Tag) error) error {
+	return nil
+}
+
+func (c fakeClient) WalkTags(ctx context.Context, owner, repo string, fn func(*github.Tag) error) error {
+	return nil
+}
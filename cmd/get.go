@@ -2,14 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
-	"time"
 
 	"github.com/dtan4/ghrls/github"
+	"github.com/dtan4/ghrls/markdown"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +20,8 @@ var getCmd = &cobra.Command{
 	Short: "Describe release information",
 	Long: `Describe release information
 
+With --os, --arch or --auto, only the download URL of the asset built for the platform is printed.
+
 Example:
 
 $ ghrls get kubernetes/kubernetes v1.5.2
@@ -29,6 +32,8 @@ Author:      saad-ali
 CreatedAt:   2017-01-12 13:51:15 +0900 JST
 PublishedAt: 2017-01-12 16:25:50 +0900 JST
 URL:         https://github.com/kubernetes/kubernetes/releases/tag/v1.5.2
+Tarball:     https://api.github.com/repos/kubernetes/kubernetes/tarball/v1.5.2
+Zipball:     https://api.github.com/repos/kubernetes/kubernetes/zipball/v1.5.2
 Assets:      https://github.com/kubernetes/kubernetes/releases/download/v1.5.2/kubernetes.tar.gz
 
 See [kubernetes-announce@](https://groups.google.com/forum/#!forum/kubernetes-announce) and [CHANGELOG](https://github.com/kubernetes/kubernetes/blob/master/CHANGELOG.md#v152) for details.
@@ -37,15 +42,77 @@ See [kubernetes-announce@](https://groups.google.com/forum/#!forum/kubernetes-an
 		`
 Additional binary downloads are linked in the [CHANGELOG](https://github.com/kubernetes/kubernetes/blob/master/CHANGELOG.md#downloads-for-v152).
 `,
+	Annotations: map[string]string{annotationRecordRepository: ""},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		timezone := time.Local
-		client := github.NewClient(rootOpts.GitHubToken)
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		client, err := newClientOrOffline(getOpts.Offline)
+		if err != nil {
+			return err
+		}
+
+		if getOpts.Web {
+			return RunGetWeb(os.Stdout, os.Stderr, args, client, openURL)
+		}
+
+		platform, err := newPlatform(&getOpts.platformOpts)
+		if err != nil {
+			return err
+		}
+
+		if platform != nil {
+			return RunGetAsset(os.Stdout, os.Stderr, args, client, *platform)
+		}
 
-		return RunGet(os.Stderr, os.Stdout, args, client, timezone)
+		var md *markdown.Renderer
+
+		if !getOpts.Raw {
+			md = newMarkdownRenderer(os.Stdout)
+		}
+
+		return runWithPager(os.Stdout, func(stdout io.Writer) error {
+			return RunGet(stdout, os.Stderr, args, client, tf, md)
+		})
 	},
 }
 
-func RunGet(stdout, stderr io.Writer, args []string, client github.ClientInterface, timezone *time.Location) error {
+var getOpts = struct {
+	platformOpts
+	Raw     bool
+	Web     bool
+	Offline bool
+}{}
+
+func RunGet(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter, md *markdown.Renderer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	ctx := context.Background()
+
+	t, err := client.DescribeRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	printRelease(stdout, t, tf, md)
+
+	return nil
+}
+
+// RunGetWeb opens the release page, or the tag tree if the tag has no release, in web browser
+func RunGetWeb(stdout, stderr io.Writer, args []string, client github.ClientInterface, open func(url string) error) error {
 	if len(args) != 2 {
 		return fmt.Errorf("Please specify repository <user/name> and tag.")
 	}
@@ -62,21 +129,71 @@ func RunGet(stdout, stderr io.Writer, args []string, client github.ClientInterfa
 
 	t, err := client.DescribeRelease(ctx, owner, repo, tag)
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			return fmt.Errorf("%s/%s@%s : not found", owner, repo, tag)
+		if !errors.Is(err, github.ErrReleaseNotFound) {
+			return err
 		}
+
+		t = &github.Tag{
+			Name: tag,
+		}
+	}
+
+	openOrPrintURL(stdout, tagURL(owner, repo, t), open)
+
+	return nil
+}
+
+// RunGetAsset prints download URL of the asset built for the given platform
+func RunGetAsset(stdout, stderr io.Writer, args []string, client github.ClientInterface, platform github.Platform) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	ctx := context.Background()
+
+	t, err := client.DescribeRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	a, err := selectPlatformAsset(owner, repo, tag, t.Release.Assets, platform)
+	if err != nil {
 		return err
 	}
 
+	fmt.Fprintln(stdout, a.URL)
+
+	return nil
+}
+
+// printRelease prints detail of the given release
+// Release notes are printed as raw Markdown if md is nil
+func printRelease(stdout io.Writer, t *github.Tag, tf *TimeFormatter, md *markdown.Renderer) {
 	w := tabwriter.NewWriter(stdout, 0, 0, 1, ' ', 0)
 	fmt.Fprintln(w, "Tag:\t"+t.Name)
 	fmt.Fprintln(w, "Commit:\t"+t.Release.Commit)
 	fmt.Fprintln(w, "Name:\t"+t.Release.Name)
 	fmt.Fprintln(w, "Author:\t"+t.Release.Author)
-	fmt.Fprintln(w, "CreatedAt:\t"+t.Release.CreatedAt.In(timezone).String())
-	fmt.Fprintln(w, "PublishedAt:\t"+t.Release.PublishedAt.In(timezone).String())
+	fmt.Fprintln(w, "CreatedAt:\t"+tf.Format(t.Release.CreatedAt))
+	fmt.Fprintln(w, "PublishedAt:\t"+tf.Format(t.Release.PublishedAt))
 	fmt.Fprintln(w, "URL:\t"+t.Release.URL)
 
+	if t.Release.TarballURL != "" {
+		fmt.Fprintln(w, "Tarball:\t"+t.Release.TarballURL)
+	}
+
+	if t.Release.ZipballURL != "" {
+		fmt.Fprintln(w, "Zipball:\t"+t.Release.ZipballURL)
+	}
+
 	if len(t.Release.ArtifactURLs) > 0 {
 		fmt.Fprintln(w, "Artifacts:\t"+t.Release.ArtifactURLs[0])
 
@@ -88,13 +205,22 @@ func RunGet(stdout, stderr io.Writer, args []string, client github.ClientInterfa
 	w.Flush()
 
 	if t.Release.Body != "" {
+		body := t.Release.Body
+
+		if md != nil {
+			body = md.Render(body)
+		}
+
 		fmt.Fprintln(stdout, "")
-		fmt.Fprintln(stdout, t.Release.Body)
+		fmt.Fprintln(stdout, body)
 	}
-
-	return nil
 }
 
 func init() {
 	RootCmd.AddCommand(getCmd)
+
+	getCmd.Flags().BoolVarP(&getOpts.Web, "web", "w", false, "Open the release in web browser instead of printing")
+	addPlatformFlags(getCmd.Flags(), &getOpts.platformOpts)
+	getCmd.Flags().BoolVar(&getOpts.Raw, "raw", false, "Print release notes as raw Markdown (default when stdout is not terminal)")
+	getCmd.Flags().BoolVar(&getOpts.Offline, "offline", false, "Read from snapshot saved by sync instead of calling GitHub API")
 }
@@ -4,13 +4,17 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dtan4/ghrls/github"
+	"github.com/dtan4/ghrls/markdown"
 )
 
 type fakeClientForGet struct {
+	fakeClient
+
 	Tag *github.Tag
 	Err error
 }
@@ -23,10 +27,6 @@ func (c fakeClientForGet) DescribeRelease(ctx context.Context, owner, repo, tag
 	return c.Tag, nil
 }
 
-func (c fakeClientForGet) ListTagsAndReleases(ctx context.Context, owner, repo string) ([]*github.Tag, error) {
-	return []*github.Tag{}, nil
-}
-
 func TestRunTag_success(t *testing.T) {
 	gmt, err := time.LoadLocation("Europe/London")
 	if err != nil {
@@ -54,6 +54,8 @@ func TestRunTag_success(t *testing.T) {
 				"CreatedAt:   2018-12-13 00:30:24 +0000 GMT\n" +
 				"PublishedAt: 2018-12-14 00:30:24 +0000 GMT\n" +
 				"URL:         https://github.com/owner/repo/releases/tag/v1\n" +
+				"Tarball:     https://api.github.com/repos/owner/repo/tarball/v1\n" +
+				"Zipball:     https://api.github.com/repos/owner/repo/zipball/v1\n" +
 				"Artifacts:   https://github.com/owner/repo/releases/download/v1/darwin.tar.gz\n" +
 				"\n" +
 				"The quick brown fox jumps over the lazy dog\n",
@@ -69,6 +71,8 @@ func TestRunTag_success(t *testing.T) {
 				"CreatedAt:   2018-12-13 09:30:24 +0900 JST\n" +
 				"PublishedAt: 2018-12-14 09:30:24 +0900 JST\n" +
 				"URL:         https://github.com/owner/repo/releases/tag/v1\n" +
+				"Tarball:     https://api.github.com/repos/owner/repo/tarball/v1\n" +
+				"Zipball:     https://api.github.com/repos/owner/repo/zipball/v1\n" +
 				"Artifacts:   https://github.com/owner/repo/releases/download/v1/darwin.tar.gz\n" +
 				"\n" +
 				"The quick brown fox jumps over the lazy dog\n",
@@ -88,7 +92,9 @@ func TestRunTag_success(t *testing.T) {
 				CreatedAt:   time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC),
 				Name:        "v1",
 				PublishedAt: time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC),
+				TarballURL:  "https://api.github.com/repos/owner/repo/tarball/v1",
 				URL:         "https://github.com/owner/repo/releases/tag/v1",
+				ZipballURL:  "https://api.github.com/repos/owner/repo/zipball/v1",
 			},
 		},
 	}
@@ -96,7 +102,7 @@ func TestRunTag_success(t *testing.T) {
 	for _, tc := range testcases {
 		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
 
-		if err := RunGet(stdout, stderr, tc.args, client, tc.timezone); err != nil {
+		if err := RunGet(stdout, stderr, tc.args, client, NewTimeFormatter(tc.timezone, ""), nil); err != nil {
 			t.Errorf("want: no error, got: %#v", err)
 		}
 
@@ -137,7 +143,7 @@ func TestRunGet_invalidArgs(t *testing.T) {
 	for _, tc := range testcases {
 		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
 
-		err := RunGet(stdout, stderr, tc.args, fakeClientForGet{}, gmt)
+		err := RunGet(stdout, stderr, tc.args, fakeClientForGet{}, NewTimeFormatter(gmt, ""), nil)
 
 		if err == nil {
 			t.Error("want: error, got: nil")
@@ -157,8 +163,13 @@ func TestRunGet_notfound(t *testing.T) {
 	}{
 		{
 			args: []string{"owner/repo", "v1"},
-			err:  fmt.Errorf("404 Not Found"),
-			want: "owner/repo@v1 : not found",
+			err:  &github.Error{Kind: github.ErrReleaseNotFound, Owner: "owner", Repo: "repo", Tag: "v1"},
+			want: "owner/repo@v1: release not found",
+		},
+		{
+			args: []string{"owner/repo", "v1"},
+			err:  &github.Error{Kind: github.ErrRepoNotFound, Owner: "owner", Repo: "repo", Authenticated: true},
+			want: "owner/repo: repository not found (check that the token has access to it)",
 		},
 		{
 			args: []string{"owner/repo", "v1"},
@@ -178,7 +189,7 @@ func TestRunGet_notfound(t *testing.T) {
 			Err: tc.err,
 		}
 
-		err := RunGet(stdout, stderr, tc.args, client, gmt)
+		err := RunGet(stdout, stderr, tc.args, client, NewTimeFormatter(gmt, ""), nil)
 
 		if err == nil {
 			t.Error("want: error, got: nil")
@@ -189,3 +200,111 @@ func TestRunGet_notfound(t *testing.T) {
 		}
 	}
 }
+
+func TestRunGet_markdown(t *testing.T) {
+	client := fakeClientForGet{
+		Tag: &github.Tag{
+			Name: "v1",
+			Release: &github.Release{
+				Body: "## Changes\n\nSee [CHANGELOG](https://github.com/owner/repo/blob/master/CHANGELOG.md) for `v1`.",
+				Name: "v1",
+			},
+		},
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	if err := RunGet(stdout, stderr, []string{"owner/repo", "v1"}, client, NewTimeFormatter(time.UTC, ""), &markdown.Renderer{}); err != nil {
+		t.Errorf("want: no error, got: %#v", err)
+	}
+
+	want := "\nChanges\n\nSee CHANGELOG (https://github.com/owner/repo/blob/master/CHANGELOG.md) for v1.\n"
+
+	if got := stdout.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("stdout want suffix:\n%q\ngot:\n%q", want, got)
+	}
+}
+
+func TestRunGetWeb(t *testing.T) {
+	testcases := []struct {
+		client  fakeClientForGet
+		openErr error
+		want    string
+		stdout  string
+	}{
+		{
+			client: fakeClientForGet{
+				Tag: &github.Tag{
+					Name: "v1",
+					Release: &github.Release{
+						URL: "https://github.com/owner/repo/releases/tag/v1",
+					},
+				},
+			},
+			want: "https://github.com/owner/repo/releases/tag/v1",
+		},
+		{
+			client: fakeClientForGet{
+				Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "owner", Repo: "repo", Tag: "v1"},
+			},
+			want: "https://github.com/owner/repo/tree/v1",
+		},
+		{
+			client: fakeClientForGet{
+				Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "owner", Repo: "repo", Tag: "v1"},
+			},
+			openErr: fmt.Errorf("no web browser available"),
+			want:    "https://github.com/owner/repo/tree/v1",
+			stdout:  "https://github.com/owner/repo/tree/v1\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+		var got string
+		open := func(url string) error {
+			got = url
+			return tc.openErr
+		}
+
+		if err := RunGetWeb(stdout, stderr, []string{"owner/repo", "v1"}, tc.client, open); err != nil {
+			t.Errorf("want: no error, got: %#v", err)
+		}
+
+		if got != tc.want {
+			t.Errorf("url want: %q, got: %q", tc.want, got)
+		}
+
+		if stdout.String() != tc.stdout {
+			t.Errorf("stdout want: %q, got: %q", tc.stdout, stdout.String())
+		}
+	}
+
+	err := RunGetWeb(new(bytes.Buffer), new(bytes.Buffer), []string{"owner/repo", "v1"}, fakeClientForGet{Err: fmt.Errorf("unexpected error")}, func(string) error { return nil })
+	if err == nil || err.Error() != "unexpected error" {
+		t.Errorf("error want: %q, got: %v", "unexpected error", err)
+	}
+}
+
+func TestRunGetAsset(t *testing.T) {
+	client := fakeClientForGet{
+		Tag: newReleaseWithAssets(
+			"tool-v1.0.0-x86_64-apple-darwin.tar.gz",
+			"tool-v1.0.0-x86_64-unknown-linux-gnu.tar.gz",
+			"tool-v1.0.0-x86_64-unknown-linux-musl.tar.gz",
+		),
+	}
+
+	stdout := &bytes.Buffer{}
+
+	if err := RunGetAsset(stdout, &bytes.Buffer{}, []string{"owner/repo", "v1.0.0"}, client, github.Platform{OS: "linux", Arch: "amd64"}); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "https://github.com/owner/repo/releases/download/v1.0.0/tool-v1.0.0-x86_64-unknown-linux-musl.tar.gz\n"
+
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
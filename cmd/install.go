@@ -0,0 +1,524 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	installedToolsFile = "installed.json"
+)
+
+// installCmd represents the install command
+var installCmd = &cobra.Command{
+	Use:   "install REPOSITORY[@TAG]",
+	Short: "Install binary from release",
+	Long: `Install binary from release
+
+The asset built for the current OS and architecture is downloaded, verified with the checksum file in the release
+if any, and extracted if it is tar.gz, tar.xz, tar.bz2, zip, gz or xz. The executable is installed into --bin-dir
+as NAME-TAG, and NAME is linked to it. The latest release is installed if TAG is omitted.
+Installed binaries are recorded in $GHRLS_DATA_DIR, $XDG_DATA_HOME/ghrls or ~/.local/share/ghrls
+to be listed by "ghrls installed" and upgraded by "ghrls upgrade".
+
+Example:
+
+$ ghrls install dtan4/ghrls@v1.1.0
+Installed dtan4/ghrls@v1.1.0 to /home/dtan4/.local/bin/ghrls
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		binDir, err := resolveBinDir(installOpts.BinDir)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunInstall(os.Stdout, os.Stderr, args, newInstaller(client, tf), binDir, installOpts.Bin, installOpts.AssetPattern)
+	},
+}
+
+var installOpts = struct {
+	AssetPattern string
+	Bin          string
+	BinDir       string
+}{}
+
+// installedTool represents binary installed by ghrls
+type installedTool struct {
+	Repository   string    `json:"repository"`
+	Tag          string    `json:"tag"`
+	Asset        string    `json:"asset"`
+	AssetPattern string    `json:"asset_pattern,omitempty"`
+	Binary       string    `json:"binary"`
+	BinDir       string    `json:"bin_dir"`
+	Path         string    `json:"path"`
+	InstalledAt  time.Time `json:"installed_at"`
+}
+
+// installer installs binary from release
+type installer struct {
+	client   github.ClientInterface
+	platform github.Platform
+	now      func() time.Time
+}
+
+func newInstaller(client github.ClientInterface, tf *TimeFormatter) *installer {
+	return &installer{
+		client:   client,
+		platform: github.Platform{OS: runtime.GOOS, Arch: runtime.GOARCH},
+		now:      tf.now,
+	}
+}
+
+func RunInstall(stdout, stderr io.Writer, args []string, inst *installer, binDir, bin, pattern string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>[@tag].")
+	}
+
+	repository, tag := args[0], ""
+
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+
+	ss := strings.Split(repository, "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", repository)
+	}
+	owner, repo := ss[0], ss[1]
+
+	if pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Invalid asset pattern: %s", pattern)
+		}
+	}
+
+	tool, err := inst.install(context.Background(), stderr, owner, repo, tag, binDir, bin, pattern)
+	if err != nil {
+		return err
+	}
+
+	if err := saveInstalledTool(tool); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Installed %s@%s to %s\n", tool.Repository, tool.Tag, filepath.Join(tool.BinDir, tool.Binary))
+
+	return nil
+}
+
+// install installs binary from release of the given tag, or the latest release if tag is empty
+func (i *installer) install(ctx context.Context, stderr io.Writer, owner, repo, tag, binDir, bin, pattern string) (*installedTool, error) {
+	if tag == "" {
+		latest, err := i.client.LatestRelease(ctx, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+
+		if latest == nil {
+			return nil, fmt.Errorf("%s/%s has no release.", owner, repo)
+		}
+
+		tag = latest.Name
+	}
+
+	t, err := i.client.DescribeRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := t.Release.Assets
+	if pattern != "" {
+		candidates = matchAssets(candidates, []string{pattern})
+	}
+
+	asset, err := selectPlatformAsset(owner, repo, tag, candidates, i.platform)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "ghrls-install-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	filename := filepath.Join(tmpDir, asset.Name)
+
+	if err := downloadAsset(ctx, i.client, owner, repo, asset, filename); err != nil {
+		return nil, err
+	}
+
+	if err := i.verify(ctx, stderr, owner, repo, t.Release.Assets, asset, filename, tmpDir); err != nil {
+		return nil, err
+	}
+
+	extractDir := filepath.Join(tmpDir, "extracted")
+
+	entries, err := extractArchive(filename, asset.Name, extractDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if bin == "" {
+		bin = repo
+	}
+
+	e, err := findExecutable(entries, bin, asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// binary in archive keeps its name, while plain binary (e.g. tool_linux_amd64) is named after the repository
+	switch detectArchiveFormat(asset.Name) {
+	case formatRaw, formatGzip, formatXz:
+	default:
+		bin = strings.TrimSuffix(path.Base(e.Name), ".exe")
+	}
+
+	installed, err := installBinary(filepath.Join(extractDir, filepath.FromSlash(e.Name)), binDir, bin, tag, i.platform.OS == "windows")
+	if err != nil {
+		return nil, err
+	}
+
+	return &installedTool{
+		Repository:   owner + "/" + repo,
+		Tag:          tag,
+		Asset:        asset.Name,
+		AssetPattern: pattern,
+		Binary:       bin,
+		BinDir:       binDir,
+		Path:         installed,
+		InstalledAt:  i.now(),
+	}, nil
+}
+
+// verify checks size and checksum of the downloaded asset
+// Verification by checksum is skipped with warning if the release has no checksum of the asset
+func (i *installer) verify(ctx context.Context, stderr io.Writer, owner, repo string, assets []*github.Asset, asset *github.Asset, filename, tmpDir string) error {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	if asset.Size > 0 && fi.Size() != int64(asset.Size) {
+		return fmt.Errorf("Size mismatch of %s: want %d, got %d", asset.Name, asset.Size, fi.Size())
+	}
+
+	var want string
+
+	if c := findChecksumAsset(assets, asset.Name); c != nil {
+		checksumFile := filepath.Join(tmpDir, c.Name)
+
+		if err := downloadAsset(ctx, i.client, owner, repo, c, checksumFile); err != nil {
+			return err
+		}
+
+		f, err := os.Open(checksumFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		want = parseChecksum(f, asset.Name)
+	}
+
+	if want == "" {
+		fmt.Fprintf(stderr, "Warning: no SHA-256 checksum of %s found in the release, skipped verification\n", asset.Name)
+		return nil
+	}
+
+	got, err := sha256File(filename)
+	if err != nil {
+		return err
+	}
+
+	if got != want {
+		return fmt.Errorf("Checksum mismatch of %s: want %s, got %s", asset.Name, want, got)
+	}
+
+	return nil
+}
+
+var checksumAssetRegexp = regexp.MustCompile(`(?i)(checksums|sha256sums)[^/]*$`)
+
+// findChecksumAsset returns the checksum file of the asset (e.g. tool.tar.gz.sha256) or the one of the release (e.g. checksums.txt)
+func findChecksumAsset(assets []*github.Asset, name string) *github.Asset {
+	for _, a := range assets {
+		if a.Name == name+".sha256" || a.Name == name+".sha256sum" {
+			return a
+		}
+	}
+
+	for _, a := range assets {
+		lower := strings.ToLower(a.Name)
+
+		// signatures of checksum file
+		if strings.HasSuffix(lower, ".sig") || strings.HasSuffix(lower, ".asc") || strings.HasSuffix(lower, ".pem") {
+			continue
+		}
+
+		if checksumAssetRegexp.MatchString(a.Name) {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// parseChecksum finds SHA-256 checksum of the given file from output of sha256sum
+// Checksum file containing only the checksum is also accepted
+func parseChecksum(r io.Reader, name string) string {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+			continue
+		}
+
+		if _, err := hex.DecodeString(fields[0]); err != nil {
+			continue
+		}
+
+		// "*" marks binary mode of sha256sum
+		if len(fields) == 1 || path.Base(strings.TrimPrefix(fields[1], "*")) == name {
+			return strings.ToLower(fields[0])
+		}
+	}
+
+	return ""
+}
+
+func sha256File(filename string) (string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// findExecutable picks executable named bin from the extracted files, or the only executable
+func findExecutable(entries []*archiveEntry, bin, assetName string) (*archiveEntry, error) {
+	candidates := []*archiveEntry{}
+
+	for _, e := range entries {
+		if e.Mode&0111 != 0 || strings.HasSuffix(strings.ToLower(e.Name), ".exe") {
+			candidates = append(candidates, e)
+		}
+	}
+
+	for _, e := range candidates {
+		if strings.TrimSuffix(path.Base(e.Name), ".exe") == bin {
+			return e, nil
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("No executable found in %s.", assetName)
+	}
+
+	names := []string{}
+	for _, e := range candidates {
+		names = append(names, e.Name)
+	}
+
+	return nil, fmt.Errorf("Cannot determine executable in %s. Please specify --bin:\n  %s", assetName, strings.Join(names, "\n  "))
+}
+
+// installBinary copies the binary into binDir as NAME-TAG and links NAME to it
+// Path to the installed binary is returned
+func installBinary(src, binDir, bin, tag string, windows bool) (string, error) {
+	ext := ""
+	if windows {
+		ext = ".exe"
+	}
+
+	// tag can have slash, e.g. tool/v1.0.0
+	versioned := bin + "-" + strings.ReplaceAll(tag, "/", "-") + ext
+	link := filepath.Join(binDir, bin+ext)
+
+	if fi, err := os.Lstat(link); err == nil && fi.Mode()&os.ModeSymlink == 0 {
+		return "", fmt.Errorf("%s already exists and is not installed by ghrls.", link)
+	}
+
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return "", err
+	}
+
+	dst := filepath.Join(binDir, versioned)
+
+	if err := copyFile(src, dst+".tmp", 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(dst+".tmp", dst); err != nil {
+		return "", err
+	}
+
+	// replace the existing link atomically
+	os.Remove(link + ".tmp")
+
+	if err := os.Symlink(versioned, link+".tmp"); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(link+".tmp", link); err != nil {
+		return "", err
+	}
+
+	return dst, nil
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// resolveBinDir returns the given directory, or ~/.local/bin if empty
+func resolveBinDir(dir string) (string, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Join(home, ".local", "bin"), nil
+	}
+
+	return filepath.Abs(dir)
+}
+
+// loadInstalledTools returns binaries installed by ghrls, sorted by repository
+func loadInstalledTools() ([]*installedTool, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, installedToolsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*installedTool{}, nil
+		}
+		return nil, err
+	}
+
+	tools := []*installedTool{}
+
+	if err := json.Unmarshal(b, &tools); err != nil {
+		return nil, fmt.Errorf("Broken registry of installed binaries %s: %s", filepath.Join(dir, installedToolsFile), err)
+	}
+
+	return tools, nil
+}
+
+// saveInstalledTool records the installed binary, replacing the previous record of the same repository and binary
+func saveInstalledTool(tool *installedTool) error {
+	tools, err := loadInstalledTools()
+	if err != nil {
+		return err
+	}
+
+	updated := []*installedTool{tool}
+
+	for _, t := range tools {
+		if t.Repository == tool.Repository && t.Binary == tool.Binary {
+			continue
+		}
+
+		updated = append(updated, t)
+	}
+
+	sort.SliceStable(updated, func(i, j int) bool {
+		if updated[i].Repository != updated[j].Repository {
+			return updated[i].Repository < updated[j].Repository
+		}
+
+		return updated[i].Binary < updated[j].Binary
+	})
+
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(updated, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(dir, installedToolsFile)
+
+	if err := ioutil.WriteFile(filename+".tmp", append(b, '\n'), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(filename+".tmp", filename)
+}
+
+func init() {
+	RootCmd.AddCommand(installCmd)
+
+	installCmd.Flags().StringVar(&installOpts.BinDir, "bin-dir", "", "Directory to install binary (default ~/.local/bin)")
+	installCmd.Flags().StringVar(&installOpts.Bin, "bin", "", "Name of executable in the asset (default repository name)")
+	installCmd.Flags().StringVar(&installOpts.AssetPattern, "asset-pattern", "", "Select asset only among ones matching the glob pattern")
+}
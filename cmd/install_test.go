@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForInstall struct {
+	fakeClient
+
+	Latest   string
+	Releases map[string]*github.Tag
+	// Files are contents of assets keyed by URL
+	Files map[string][]byte
+	// Errs are returned by LatestRelease for the repositories keyed by owner/repo
+	Errs map[string]error
+}
+
+func (c *fakeClientForInstall) LatestRelease(ctx context.Context, owner, repo string) (*github.Tag, error) {
+	if err, ok := c.Errs[owner+"/"+repo]; ok {
+		return nil, err
+	}
+
+	if c.Latest == "" {
+		return nil, nil
+	}
+
+	return c.Releases[c.Latest], nil
+}
+
+func (c *fakeClientForInstall) DescribeRelease(ctx context.Context, owner, repo, tag string) (*github.Tag, error) {
+	t, ok := c.Releases[tag]
+	if !ok {
+		return nil, &github.Error{Kind: github.ErrReleaseNotFound, Owner: owner, Repo: repo, Tag: tag}
+	}
+
+	return t, nil
+}
+
+func (c *fakeClientForInstall) OpenReleaseAsset(ctx context.Context, owner, repo string, asset *github.Asset, offset, length int64) (io.ReadCloser, error) {
+	b, ok := c.Files[asset.URL]
+	if !ok {
+		return nil, fmt.Errorf("failed to download %s: 404 Not Found", asset.Name)
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
+}
+
+// fakeReleaseServer serves assets of fake releases
+type fakeReleaseServer struct {
+	client *fakeClientForInstall
+	files  map[string][]byte
+}
+
+func newFakeReleaseServer() *fakeReleaseServer {
+	files := map[string][]byte{}
+
+	return &fakeReleaseServer{
+		client: &fakeClientForInstall{Releases: map[string]*github.Tag{}, Files: files},
+		files:  files,
+	}
+}
+
+// addRelease adds release with the given assets, checksums.txt is generated if checksum is true
+func (s *fakeReleaseServer) addRelease(tag string, assets map[string][]byte, checksum bool) {
+	t := &github.Tag{
+		Name:    tag,
+		Release: &github.Release{Assets: []*github.Asset{}},
+	}
+
+	sums := ""
+
+	for name, body := range assets {
+		url := "https://github.com/owner/tool/releases/download/" + tag + "/" + name
+		s.files[url] = body
+		t.Release.Assets = append(t.Release.Assets, &github.Asset{Name: name, Size: len(body), URL: url})
+
+		h := sha256.Sum256(body)
+		sums += hex.EncodeToString(h[:]) + "  " + name + "\n"
+	}
+
+	if checksum {
+		url := "https://github.com/owner/tool/releases/download/" + tag + "/checksums.txt"
+		s.files[url] = []byte(sums)
+		t.Release.Assets = append(t.Release.Assets, &github.Asset{Name: "checksums.txt", Size: len(sums), URL: url})
+	}
+
+	s.client.Releases[tag] = t
+	s.client.Latest = tag
+}
+
+func (s *fakeReleaseServer) installer() *installer {
+	return &installer{
+		client:   s.client,
+		platform: github.Platform{OS: "linux", Arch: "amd64"},
+		now: func() time.Time {
+			return time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+func newToolArchive(t *testing.T, version string, files ...testArchiveFile) []byte {
+	if len(files) == 0 {
+		files = []testArchiveFile{
+			{Name: "tool_" + version + "/tool", Body: "tool " + version, Mode: 0755},
+			{Name: "tool_" + version + "/LICENSE", Body: "MIT", Mode: 0644},
+		}
+	}
+
+	return newTestArchive(t, formatTarGzip, files)
+}
+
+func assertInstalled(t *testing.T, binDir, link, want string) {
+	t.Helper()
+
+	target, err := os.Readlink(filepath.Join(binDir, link))
+	if err != nil {
+		t.Fatalf("want: symlink, got: %s", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(binDir, target))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != want {
+		t.Errorf("binary want: %q, got: %q", want, string(b))
+	}
+}
+
+func TestRunInstall_success(t *testing.T) {
+	setDataDir(t)
+
+	s := newFakeReleaseServer()
+	s.addRelease("v1.0.0", map[string][]byte{
+		"tool_1.0.0_linux_amd64.tar.gz":  newToolArchive(t, "1.0.0"),
+		"tool_1.0.0_darwin_arm64.tar.gz": newToolArchive(t, "1.0.0"),
+	}, true)
+	s.addRelease("v1.1.0", map[string][]byte{
+		"tool_1.1.0_linux_amd64.tar.gz": newToolArchive(t, "1.1.0"),
+	}, false)
+
+	binDir := t.TempDir()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunInstall(stdout, stderr, []string{"owner/tool@v1.0.0"}, s.installer(), binDir, "", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := fmt.Sprintf("Installed owner/tool@v1.0.0 to %s\n", filepath.Join(binDir, "tool"))
+
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	if stderr.String() != "" {
+		t.Errorf("stderr want: empty, got: %q", stderr.String())
+	}
+
+	assertInstalled(t, binDir, "tool", "tool 1.0.0")
+
+	// latest release without checksum
+	stdout.Reset()
+
+	if err := RunInstall(stdout, stderr, []string{"owner/tool"}, s.installer(), binDir, "", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	wantStderr := "Warning: no SHA-256 checksum of tool_1.1.0_linux_amd64.tar.gz found in the release, skipped verification\n"
+
+	if stderr.String() != wantStderr {
+		t.Errorf("stderr want: %q, got: %q", wantStderr, stderr.String())
+	}
+
+	assertInstalled(t, binDir, "tool", "tool 1.1.0")
+
+	if _, err := os.Stat(filepath.Join(binDir, "tool-v1.0.0")); err != nil {
+		t.Errorf("want: previous version kept, got: %s", err)
+	}
+
+	tools, err := loadInstalledTools()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tools) != 1 {
+		t.Fatalf("want: 1 installed tool, got: %d", len(tools))
+	}
+
+	wantTool := installedTool{
+		Repository:  "owner/tool",
+		Tag:         "v1.1.0",
+		Asset:       "tool_1.1.0_linux_amd64.tar.gz",
+		Binary:      "tool",
+		BinDir:      binDir,
+		Path:        filepath.Join(binDir, "tool-v1.1.0"),
+		InstalledAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if *tools[0] != wantTool {
+		t.Errorf("want: %+v, got: %+v", wantTool, *tools[0])
+	}
+}
+
+func TestRunInstall_plainBinary(t *testing.T) {
+	setDataDir(t)
+
+	s := newFakeReleaseServer()
+	s.addRelease("v1.0.0", map[string][]byte{
+		"tool-linux-amd64":  []byte("linux binary"),
+		"tool-darwin-amd64": []byte("darwin binary"),
+	}, true)
+
+	binDir := t.TempDir()
+
+	if err := RunInstall(&bytes.Buffer{}, &bytes.Buffer{}, []string{"owner/tool"}, s.installer(), binDir, "", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	assertInstalled(t, binDir, "tool", "linux binary")
+}
+
+func TestRunInstall_error(t *testing.T) {
+	testcases := []struct {
+		args   []string
+		assets map[string][]byte
+		setup  func(s *fakeReleaseServer, binDir string)
+		want   string
+	}{
+		{
+			args: []string{"owner/tool@v9.9.9"},
+			want: "owner/tool@v9.9.9: release not found",
+		},
+		{
+			args: []string{"owner"},
+			want: "Invalid repository name: owner",
+		},
+		{
+			args: []string{"owner/tool"},
+			assets: map[string][]byte{
+				"tool_1.0.0_linux_amd64.tar.gz": newToolArchive(t, "1.0.0",
+					testArchiveFile{Name: "foo", Body: "foo", Mode: 0755},
+					testArchiveFile{Name: "bar", Body: "bar", Mode: 0755},
+				),
+			},
+			want: "Cannot determine executable in tool_1.0.0_linux_amd64.tar.gz. Please specify --bin:\n  foo\n  bar",
+		},
+		{
+			args: []string{"owner/tool"},
+			assets: map[string][]byte{
+				"tool_1.0.0_linux_amd64.tar.gz": newToolArchive(t, "1.0.0"),
+			},
+			setup: func(s *fakeReleaseServer, binDir string) {
+				s.files["https://github.com/owner/tool/releases/download/v1.0.0/tool_1.0.0_linux_amd64.tar.gz"] = newToolArchive(t, "1.0.1")
+			},
+			want: "Checksum mismatch of tool_1.0.0_linux_amd64.tar.gz",
+		},
+		{
+			args: []string{"owner/tool"},
+			assets: map[string][]byte{
+				"tool_1.0.0_linux_amd64.tar.gz": newToolArchive(t, "1.0.0"),
+			},
+			setup: func(s *fakeReleaseServer, binDir string) {
+				if err := ioutil.WriteFile(filepath.Join(binDir, "tool"), []byte("other"), 0755); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "tool already exists and is not installed by ghrls.",
+		},
+	}
+
+	for _, tc := range testcases {
+		setDataDir(t)
+
+		s := newFakeReleaseServer()
+		if tc.assets != nil {
+			s.addRelease("v1.0.0", tc.assets, true)
+		}
+
+		binDir := t.TempDir()
+
+		if tc.setup != nil {
+			tc.setup(s, binDir)
+		}
+
+		err := RunInstall(&bytes.Buffer{}, &bytes.Buffer{}, tc.args, s.installer(), binDir, "", "")
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestRunUpgrade(t *testing.T) {
+	setDataDir(t)
+
+	s := newFakeReleaseServer()
+	s.addRelease("v1.0.0", map[string][]byte{
+		"tool_1.0.0_linux_amd64.tar.gz": newToolArchive(t, "1.0.0"),
+	}, true)
+
+	binDir := t.TempDir()
+
+	if err := RunInstall(&bytes.Buffer{}, &bytes.Buffer{}, []string{"owner/tool"}, s.installer(), binDir, "", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	stdout := &bytes.Buffer{}
+
+	if err := RunUpgrade(stdout, &bytes.Buffer{}, []string{}, s.installer(), false); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := "owner/tool: v1.0.0 is up to date\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	s.addRelease("v1.1.0", map[string][]byte{
+		"tool_1.1.0_linux_amd64.tar.gz": newToolArchive(t, "1.1.0"),
+	}, true)
+
+	// dry run
+	stdout.Reset()
+
+	if err := RunUpgrade(stdout, &bytes.Buffer{}, []string{"owner/tool"}, s.installer(), true); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := "owner/tool: v1.0.0 -> v1.1.0\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	assertInstalled(t, binDir, "tool", "tool 1.0.0")
+
+	stdout.Reset()
+
+	if err := RunUpgrade(stdout, &bytes.Buffer{}, []string{}, s.installer(), false); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := "owner/tool: v1.0.0 -> v1.1.0\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	assertInstalled(t, binDir, "tool", "tool 1.1.0")
+
+	if err := RunUpgrade(stdout, &bytes.Buffer{}, []string{"owner/other"}, s.installer(), false); err == nil {
+		t.Errorf("want: error for not installed repository, got: nil")
+	}
+}
+
+func TestRunUpgrade_partialFailure(t *testing.T) {
+	setDataDir(t)
+
+	s := newFakeReleaseServer()
+	s.addRelease("v1.0.0", map[string][]byte{
+		"tool_1.0.0_linux_amd64.tar.gz": newToolArchive(t, "1.0.0"),
+	}, true)
+
+	binDir := t.TempDir()
+
+	if err := RunInstall(&bytes.Buffer{}, &bytes.Buffer{}, []string{"owner/tool"}, s.installer(), binDir, "", ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if err := saveInstalledTool(&installedTool{Repository: "owner/broken", Tag: "v1.0.0", Binary: "broken", BinDir: binDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.addRelease("v1.1.0", map[string][]byte{
+		"tool_1.1.0_linux_amd64.tar.gz": newToolArchive(t, "1.1.0"),
+	}, true)
+	s.client.Errs = map[string]error{
+		"owner/broken": &github.Error{Kind: github.ErrRepoNotFound, Owner: "owner", Repo: "broken"},
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	err := RunUpgrade(stdout, stderr, []string{}, s.installer(), false)
+	if err == nil {
+		t.Fatal("want: error, got: nil")
+	}
+
+	if want := "Failed to upgrade 1 of 2 tools: owner/broken"; err.Error() != want {
+		t.Errorf("want: %q, got: %q", want, err.Error())
+	}
+
+	if want := "owner/tool: v1.0.0 -> v1.1.0\n"; stdout.String() != want {
+		t.Errorf("stdout want: %q, got: %q", want, stdout.String())
+	}
+
+	if want := "Failed to upgrade owner/broken: owner/broken: repository not found (set GITHUB_TOKEN for private repos)\n"; stderr.String() != want {
+		t.Errorf("stderr want: %q, got: %q", want, stderr.String())
+	}
+
+	assertInstalled(t, binDir, "tool", "tool 1.1.0")
+}
+
+func TestRunInstalled(t *testing.T) {
+	setDataDir(t)
+
+	tf := NewTimeFormatter(time.UTC, "")
+
+	for _, tool := range []*installedTool{
+		{Repository: "owner/tool", Tag: "v1.0.0", Binary: "tool", InstalledAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Repository: "dtan4/ghrls", Tag: "v1.1.0", Binary: "ghrls", InstalledAt: time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)},
+	} {
+		if err := saveInstalledTool(tool); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdout := &bytes.Buffer{}
+
+	if err := RunInstalled(stdout, &bytes.Buffer{}, []string{}, tf); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "" +
+		"REPOSITORY     TAG       BINARY    INSTALLEDAT\n" +
+		"dtan4/ghrls    v1.1.0    ghrls     2021-03-02 00:00:00 +0000 UTC\n" +
+		"owner/tool     v1.0.0    tool      2021-03-01 00:00:00 +0000 UTC\n"
+
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sum := "67344958325a70348db5c4e35e59f9c3552232cdc34defb8a0a799ed91c671a3"
+
+	testcases := []struct {
+		body string
+		name string
+		want string
+	}{
+		{
+			body: "0000000000000000000000000000000000000000000000000000000000000000  other.tar.gz\n" + sum + "  tool.tar.gz\n",
+			name: "tool.tar.gz",
+			want: sum,
+		},
+		{
+			body: sum + " *dist/tool.tar.gz\n",
+			name: "tool.tar.gz",
+			want: sum,
+		},
+		{
+			// tool.tar.gz.sha256
+			body: strings.ToUpper(sum) + "\n",
+			name: "tool.tar.gz",
+			want: sum,
+		},
+		{
+			body: sum + "  other.tar.gz\n",
+			name: "tool.tar.gz",
+			want: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := parseChecksum(strings.NewReader(tc.body), tc.name); got != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
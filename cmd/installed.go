@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dtan4/ghrls/semver"
+	"github.com/spf13/cobra"
+)
+
+// installedCmd represents the installed command
+var installedCmd = &cobra.Command{
+	Use:   "installed",
+	Short: "List binaries installed by ghrls",
+	Long: `List binaries installed by ghrls
+
+Example:
+
+$ ghrls installed
+REPOSITORY     TAG       BINARY    INSTALLEDAT
+dtan4/ghrls    v1.1.0    ghrls     2021-03-01 09:00:00 +0900 JST
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		return RunInstalled(os.Stdout, os.Stderr, args, tf)
+	},
+}
+
+// upgradeCmd represents the upgrade command
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [REPOSITORY...]",
+	Short: "Upgrade binaries installed by ghrls to the latest release",
+	Long: `Upgrade binaries installed by ghrls to the latest release
+
+All installed binaries are upgraded if no repository is given.
+Binaries of the previous versions are kept, so they can be restored by relinking.
+
+Example:
+
+$ ghrls upgrade
+dtan4/ghrls: v1.0.0 -> v1.1.0
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunUpgrade(os.Stdout, os.Stderr, args, newInstaller(client, tf), upgradeOpts.DryRun)
+	},
+}
+
+var upgradeOpts = struct {
+	DryRun bool
+}{}
+
+var (
+	installedHeaders = []string{
+		"REPOSITORY",
+		"TAG",
+		"BINARY",
+		"INSTALLEDAT",
+	}
+)
+
+func RunInstalled(stdout, stderr io.Writer, args []string, tf *TimeFormatter) error {
+	tools, err := loadInstalledTools()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, strings.Join(installedHeaders, "\t"))
+
+	for _, t := range tools {
+		fmt.Fprintln(w, strings.Join([]string{t.Repository, t.Tag, t.Binary, tf.Format(t.InstalledAt)}, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+// RunUpgrade installs the latest release of the given repositories, or all installed ones if no repository is given
+func RunUpgrade(stdout, stderr io.Writer, args []string, inst *installer, dryRun bool) error {
+	tools, err := loadInstalledTools()
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		selected := []*installedTool{}
+
+		for _, repository := range args {
+			found := false
+
+			for _, t := range tools {
+				if t.Repository == repository {
+					selected = append(selected, t)
+					found = true
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("%s is not installed by ghrls.", repository)
+			}
+		}
+
+		tools = selected
+	}
+
+	ctx := context.Background()
+
+	failed := []string{}
+
+	// one failure should not block upgrading the others
+	for _, t := range tools {
+		if err := upgradeTool(ctx, stdout, stderr, inst, t, dryRun); err != nil {
+			fmt.Fprintf(stderr, "Failed to upgrade %s: %s\n", t.Repository, err)
+			failed = append(failed, t.Repository)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Failed to upgrade %d of %d tools: %s", len(failed), len(tools), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// upgradeTool installs the latest release of the tool if it is newer than the installed one
+func upgradeTool(ctx context.Context, stdout, stderr io.Writer, inst *installer, t *installedTool, dryRun bool) error {
+	ss := strings.Split(t.Repository, "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", t.Repository)
+	}
+	owner, repo := ss[0], ss[1]
+
+	latest, err := inst.client.LatestRelease(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if latest == nil || !isNewerTag(latest.Name, t.Tag) {
+		fmt.Fprintf(stdout, "%s: %s is up to date\n", t.Repository, t.Tag)
+		return nil
+	}
+
+	if !dryRun {
+		tool, err := inst.install(ctx, stderr, owner, repo, latest.Name, t.BinDir, t.Binary, t.AssetPattern)
+		if err != nil {
+			return err
+		}
+
+		if err := saveInstalledTool(tool); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(stdout, "%s: %s -> %s\n", t.Repository, t.Tag, latest.Name)
+
+	return nil
+}
+
+// isNewerTag reports whether tag is newer than the installed one
+// Tags not following SemVer are compared just by equality
+func isNewerTag(tag, installed string) bool {
+	v, err := semver.Parse(tag)
+	if err != nil {
+		return tag != installed
+	}
+
+	w, err := semver.Parse(installed)
+	if err != nil {
+		return tag != installed
+	}
+
+	return v.Compare(w) > 0
+}
+
+func init() {
+	RootCmd.AddCommand(installedCmd)
+	RootCmd.AddCommand(upgradeCmd)
+
+	upgradeCmd.Flags().BoolVar(&upgradeOpts.DryRun, "dry-run", false, "Print binaries to be upgraded without installing them")
+}
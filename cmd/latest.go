@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// latestCmd represents the latest command
+var latestCmd = &cobra.Command{
+	Use:   "latest REPOSITORY",
+	Short: "Print tag of the latest release",
+	Long: `Print tag of the latest release
+
+The latest release is the most recent published release which is not prerelease.
+Use "ghrls get REPOSITORY $(ghrls latest REPOSITORY)" to describe it.
+
+Example:
+
+$ ghrls latest kubernetes/kubernetes
+v1.5.2
+`,
+	Annotations: map[string]string{annotationRecordRepository: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClientOrOffline(latestOpts.Offline)
+		if err != nil {
+			return err
+		}
+
+		return RunLatest(os.Stdout, os.Stderr, args, client)
+	},
+}
+
+var latestOpts = struct {
+	Offline bool
+}{}
+
+// RunLatest prints tag of the latest release of the given repository
+func RunLatest(stdout, stderr io.Writer, args []string, client github.ClientInterface) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx := context.Background()
+
+	t, err := client.LatestRelease(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return fmt.Errorf("%s/%s has no release.", owner, repo)
+	}
+
+	fmt.Fprintln(stdout, t.Name)
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(latestCmd)
+
+	latestCmd.Flags().BoolVar(&latestOpts.Offline, "offline", false, "Read from snapshot saved by sync instead of calling GitHub API")
+}
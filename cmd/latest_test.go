@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForLatest struct {
+	fakeClient
+
+	Tag string
+}
+
+func (c fakeClientForLatest) LatestRelease(ctx context.Context, owner, repo string) (*github.Tag, error) {
+	if c.Tag == "" {
+		return nil, nil
+	}
+
+	return &github.Tag{Name: c.Tag, Release: &github.Release{Name: c.Tag}}, nil
+}
+
+func TestRunLatest(t *testing.T) {
+	testcases := []struct {
+		args    []string
+		client  fakeClientForLatest
+		want    string
+		wantErr string
+	}{
+		{
+			args:   []string{"dtan4/ghrls"},
+			client: fakeClientForLatest{Tag: "v1.1.0"},
+			want:   "v1.1.0\n",
+		},
+		{
+			args:    []string{"dtan4/ghrls"},
+			client:  fakeClientForLatest{},
+			wantErr: "dtan4/ghrls has no release.",
+		},
+		{
+			args:    []string{"dtan4"},
+			wantErr: "Invalid repository name: dtan4",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout := &bytes.Buffer{}
+
+		err := RunLatest(stdout, &bytes.Buffer{}, tc.args, tc.client)
+		if tc.wantErr != "" {
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("want: %q, got: %v", tc.wantErr, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, stdout.String())
+		}
+	}
+}
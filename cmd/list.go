@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"regexp"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -33,14 +35,44 @@ v1.5.0            TAG+RELEASE    2016-12-13 08:29:43 +0900 JST    v1.5.0
 v1.5.0-beta.3     TAG+RELEASE    2016-12-09 06:52:35 +0900 JST    v1.5.0-beta.3
 v1.5.0-beta.2     TAG+RELEASE    2016-11-25 07:29:04 +0900 JST    v1.5.0-beta.2
 `,
+	Annotations: map[string]string{annotationRecordRepository: ""},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		timezone := time.Local
-		client := github.NewClient(rootOpts.GitHubToken)
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		if listOpts.Web {
+			return RunListWeb(os.Stdout, os.Stderr, args, github.TagType(listOpts.Type), openURL)
+		}
+
+		filter, err := newListFilter(listOpts.Type, listOpts.Since, listOpts.Until, listOpts.Match, listOpts.Regex, listOpts.Limit, tf.location)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClientOrOffline(listOpts.Offline)
+		if err != nil {
+			return err
+		}
 
-		return RunList(os.Stderr, os.Stdout, args, client, timezone)
+		return runWithPager(os.Stdout, func(stdout io.Writer) error {
+			return RunList(stdout, os.Stderr, args, client, tf, filter)
+		})
 	},
 }
 
+var listOpts = struct {
+	Type    string
+	Since   string
+	Until   string
+	Match   string
+	Regex   string
+	Limit   int
+	Web     bool
+	Offline bool
+}{}
+
 var (
 	headers = []string{
 		"TAG",
@@ -50,7 +82,7 @@ var (
 	}
 )
 
-func RunList(stdout, stderr io.Writer, args []string, client github.ClientInterface, timezone *time.Location) error {
+func RunList(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter, filter *github.ListFilter) error {
 	if len(args) != 1 {
 		return fmt.Errorf("Please specify repository <user/name>.")
 	}
@@ -63,11 +95,8 @@ func RunList(stdout, stderr io.Writer, args []string, client github.ClientInterf
 
 	ctx := context.Background()
 
-	tags, err := client.ListTagsAndReleases(ctx, owner, repo)
+	tags, err := client.ListTagsAndReleases(ctx, owner, repo, filter)
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			return fmt.Errorf("%s/%s: not found", owner, repo)
-		}
 		return err
 	}
 
@@ -78,7 +107,7 @@ func RunList(stdout, stderr io.Writer, args []string, client github.ClientInterf
 		ss := []string{}
 
 		if tag.Release != nil {
-			ss = append(ss, tag.Name, "TAG+RELEASE", tag.Release.CreatedAt.In(timezone).String(), tag.Release.Name)
+			ss = append(ss, tag.Name, "TAG+RELEASE", tf.Format(tag.Release.CreatedAt), tag.Release.Name)
 		} else {
 			ss = append(ss, tag.Name, "TAG", "", "")
 		}
@@ -91,6 +120,131 @@ func RunList(stdout, stderr io.Writer, args []string, client github.ClientInterf
 	return nil
 }
 
+// RunListWeb opens the releases page, or the tags page for TagTypeTag, in web browser
+func RunListWeb(stdout, stderr io.Writer, args []string, tagType github.TagType, open func(url string) error) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	url := releasesURL(owner, repo)
+	if tagType == github.TagTypeTag {
+		url = fmt.Sprintf("https://github.com/%s/%s/tags", owner, repo)
+	}
+
+	openOrPrintURL(stdout, url, open)
+
+	return nil
+}
+
+// newListFilter builds filter from the list command options
+// since and until are either date (2006-01-02) or RFC3339 timestamp, and until is inclusive
+func newListFilter(tagType, since, until, match, regex string, limit int, loc *time.Location) (*github.ListFilter, error) {
+	filter := &github.ListFilter{
+		Limit: limit,
+	}
+
+	switch t := github.TagType(tagType); t {
+	case "", github.TagTypeAll, github.TagTypeTag, github.TagTypeRelease:
+		filter.Type = t
+	default:
+		return nil, fmt.Errorf("Invalid type: %s (must be tag, release or all)", tagType)
+	}
+
+	if limit < 0 {
+		return nil, fmt.Errorf("Invalid limit: %d", limit)
+	}
+
+	if since != "" {
+		t, _, err := parseDate(since, loc)
+		if err != nil {
+			return nil, err
+		}
+		filter.Since = t
+	}
+
+	if until != "" {
+		t, dateOnly, err := parseDate(until, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		if dateOnly {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		filter.Until = t
+	}
+
+	if filter.Type == github.TagTypeTag && (since != "" || until != "") {
+		return nil, fmt.Errorf("--since and --until cannot be used with --type tag, plain tags have no timestamp")
+	}
+
+	var matchers []func(string) bool
+
+	if match != "" {
+		if _, err := path.Match(match, ""); err != nil {
+			return nil, fmt.Errorf("Invalid pattern: %s", match)
+		}
+
+		matchers = append(matchers, func(name string) bool {
+			ok, _ := path.Match(match, name)
+			return ok
+		})
+	}
+
+	if regex != "" {
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid regular expression: %s", regex)
+		}
+
+		matchers = append(matchers, re.MatchString)
+	}
+
+	if len(matchers) > 0 {
+		filter.Match = func(name string) bool {
+			for _, m := range matchers {
+				if !m(name) {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	return filter, nil
+}
+
+// parseDate parses date (2006-01-02) or RFC3339 timestamp
+// dateOnly reports whether the value has no time part
+func parseDate(s string, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, true, nil
+	}
+
+	t, err = time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("Invalid date: %s (must be YYYY-MM-DD or RFC3339)", s)
+	}
+
+	return t, false, nil
+}
+
 func init() {
 	RootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listOpts.Type, "type", string(github.TagTypeAll), "Kind of tags to list: tag, release or all")
+	listCmd.Flags().StringVar(&listOpts.Since, "since", "", "List only releases created at or after the date (YYYY-MM-DD or RFC3339)")
+	listCmd.Flags().StringVar(&listOpts.Until, "until", "", "List only releases created at or before the date (YYYY-MM-DD or RFC3339)")
+	listCmd.Flags().StringVar(&listOpts.Match, "match", "", "List only tags matching the glob pattern (e.g. 'v1.2*')")
+	listCmd.Flags().StringVar(&listOpts.Regex, "regex", "", "List only tags matching the regular expression")
+	listCmd.Flags().BoolVarP(&listOpts.Web, "web", "w", false, "Open the releases page in web browser instead of printing")
+	listCmd.Flags().IntVar(&listOpts.Limit, "limit", 0, "Maximum number of tags to list (0 means unlimited)")
+	listCmd.Flags().BoolVar(&listOpts.Offline, "offline", false, "Read from snapshot saved by sync instead of calling GitHub API")
 }
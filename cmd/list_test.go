@@ -11,15 +11,13 @@ import (
 )
 
 type fakeClientForList struct {
+	fakeClient
+
 	Tags []*github.Tag
 	Err  error
 }
 
-func (c fakeClientForList) DescribeRelease(ctx context.Context, owner, repo, tag string) (*github.Tag, error) {
-	return &github.Tag{}, nil
-}
-
-func (c fakeClientForList) ListTagsAndReleases(ctx context.Context, owner, repo string) ([]*github.Tag, error) {
+func (c fakeClientForList) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *github.ListFilter) ([]*github.Tag, error) {
 	if c.Err != nil {
 		return []*github.Tag{}, c.Err
 	}
@@ -86,7 +84,7 @@ func TestRunList_success(t *testing.T) {
 	for _, tc := range testcases {
 		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
 
-		if err := RunList(stdout, stderr, tc.args, client, tc.timezone); err != nil {
+		if err := RunList(stdout, stderr, tc.args, client, NewTimeFormatter(tc.timezone, ""), nil); err != nil {
 			t.Errorf("want: no error, got: %#v", err)
 		}
 
@@ -123,7 +121,7 @@ func TestRunList_invalidArgs(t *testing.T) {
 	for _, tc := range testcases {
 		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
 
-		err := RunList(stdout, stderr, tc.args, fakeClientForList{}, gmt)
+		err := RunList(stdout, stderr, tc.args, fakeClientForList{}, NewTimeFormatter(gmt, ""), nil)
 
 		if err == nil {
 			t.Error("want: error, got: nil")
@@ -143,8 +141,13 @@ func TestRunList_notfound(t *testing.T) {
 	}{
 		{
 			args: []string{"owner/repo"},
-			err:  fmt.Errorf("404 Not Found"),
-			want: "owner/repo: not found",
+			err:  &github.Error{Kind: github.ErrRepoNotFound, Owner: "owner", Repo: "repo"},
+			want: "owner/repo: repository not found (set GITHUB_TOKEN for private repos)",
+		},
+		{
+			args: []string{"owner/repo"},
+			err:  &github.Error{Kind: github.ErrUnauthorized, Owner: "owner", Repo: "repo", StatusCode: 403, Message: "Resource not accessible by integration"},
+			want: "owner/repo: permission denied: Resource not accessible by integration (check that the token has the required scopes)",
 		},
 		{
 			args: []string{"owner/repo"},
@@ -164,10 +167,114 @@ func TestRunList_notfound(t *testing.T) {
 			Err: tc.err,
 		}
 
-		err := RunList(stdout, stderr, tc.args, client, gmt)
+		err := RunList(stdout, stderr, tc.args, client, NewTimeFormatter(gmt, ""), nil)
+
+		if err == nil {
+			t.Error("want: error, got: nil")
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("error want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestNewListFilter(t *testing.T) {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := newListFilter("release", "2024-01-01", "2024-03-31", "v1.2*", `-rc\.`, 10, jst)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if filter.Type != github.TagTypeRelease {
+		t.Errorf("type want: %q, got: %q", github.TagTypeRelease, filter.Type)
+	}
+
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, jst); !filter.Since.Equal(want) {
+		t.Errorf("since want: %s, got: %s", want, filter.Since)
+	}
+
+	if want := time.Date(2024, 3, 31, 23, 59, 59, 999999999, jst); !filter.Until.Equal(want) {
+		t.Errorf("until want: %s, got: %s", want, filter.Until)
+	}
+
+	if filter.Limit != 10 {
+		t.Errorf("limit want: 10, got: %d", filter.Limit)
+	}
+
+	for name, want := range map[string]bool{
+		"v1.2.0-rc.1": true,
+		"v1.2.0":      false,
+		"v1.3.0-rc.1": false,
+	} {
+		if got := filter.Match(name); got != want {
+			t.Errorf("match %q want: %t, got: %t", name, want, got)
+		}
+	}
+
+	filter, err = newListFilter("all", "", "2024-01-01T12:00:00Z", "", "", 0, jst)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC); !filter.Until.Equal(want) {
+		t.Errorf("until want: %s, got: %s", want, filter.Until)
+	}
+
+	if filter.Match != nil {
+		t.Error("match want: nil")
+	}
+}
 
+func TestNewListFilter_invalid(t *testing.T) {
+	testcases := []struct {
+		tagType string
+		since   string
+		match   string
+		regex   string
+		limit   int
+		want    string
+	}{
+		{
+			tagType: "foo",
+			want:    "Invalid type: foo (must be tag, release or all)",
+		},
+		{
+			tagType: "all",
+			limit:   -1,
+			want:    "Invalid limit: -1",
+		},
+		{
+			tagType: "all",
+			since:   "yesterday",
+			want:    "Invalid date: yesterday (must be YYYY-MM-DD or RFC3339)",
+		},
+		{
+			tagType: "tag",
+			since:   "2024-01-01",
+			want:    "--since and --until cannot be used with --type tag, plain tags have no timestamp",
+		},
+		{
+			tagType: "all",
+			match:   "v1.[",
+			want:    "Invalid pattern: v1.[",
+		},
+		{
+			tagType: "all",
+			regex:   "v1.(",
+			want:    "Invalid regular expression: v1.(",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := newListFilter(tc.tagType, tc.since, "", tc.match, tc.regex, tc.limit, time.UTC)
 		if err == nil {
 			t.Error("want: error, got: nil")
+			continue
 		}
 
 		if err.Error() != tc.want {
@@ -175,3 +282,41 @@ func TestRunList_notfound(t *testing.T) {
 		}
 	}
 }
+
+func TestRunListWeb(t *testing.T) {
+	testcases := []struct {
+		tagType github.TagType
+		want    string
+	}{
+		{
+			tagType: github.TagTypeAll,
+			want:    "https://github.com/owner/repo/releases",
+		},
+		{
+			tagType: github.TagTypeTag,
+			want:    "https://github.com/owner/repo/tags",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+		var got string
+		open := func(url string) error {
+			got = url
+			return nil
+		}
+
+		if err := RunListWeb(stdout, stderr, []string{"owner/repo"}, tc.tagType, open); err != nil {
+			t.Errorf("want: no error, got: %#v", err)
+		}
+
+		if got != tc.want {
+			t.Errorf("url want: %q, got: %q", tc.want, got)
+		}
+
+		if stdout.String() != "" {
+			t.Errorf("stdout want: empty, got: %q", stdout.String())
+		}
+	}
+}
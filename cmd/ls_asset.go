@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// lsAssetCmd represents the ls-asset command
+var lsAssetCmd = &cobra.Command{
+	Use:   "ls-asset REPOSITORY TAG [ASSET]",
+	Short: "List files in archive asset without downloading it",
+	Long: `List files in archive asset without downloading it
+
+tar, tar.gz, tar.xz, tar.bz2 and zip are supported. tar archive is streamed, and only the file index is read from zip archive.
+ASSET can be omitted with --os, --arch or --auto.
+
+Example:
+
+$ ghrls ls-asset dtan4/ghrls v1.1.0 ghrls_1.1.0_linux_amd64.tar.gz
+PATH         SIZE       MODE
+LICENSE      1077       -rw-r--r--
+README.md    6473       -rw-r--r--
+ghrls        9510912    -rwxr-xr-x
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		platform, err := newPlatform(&lsAssetOpts.platformOpts)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunLsAsset(os.Stdout, os.Stderr, args, client, platform)
+	},
+}
+
+var lsAssetOpts = struct {
+	platformOpts
+}{}
+
+var (
+	lsAssetHeaders = []string{
+		"PATH",
+		"SIZE",
+		"MODE",
+	}
+)
+
+func RunLsAsset(stdout, stderr io.Writer, args []string, client github.ClientInterface, platform *github.Platform) error {
+	if len(args) != 3 && (platform == nil || len(args) != 2) {
+		return fmt.Errorf("Please specify repository <user/name>, tag and asset name.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag, name := args[1], ""
+	if len(args) == 3 {
+		name = args[2]
+	}
+
+	ctx := context.Background()
+
+	asset, err := findReleaseAsset(ctx, client, owner, repo, tag, name, platform)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, strings.Join(lsAssetHeaders, "\t"))
+
+	err = walkRemoteArchive(ctx, client, owner, repo, asset, func(e *archiveEntry, r io.Reader) error {
+		fmt.Fprintln(w, strings.Join([]string{e.Name, strconv.FormatInt(e.Size, 10), e.Mode.String()}, "\t"))
+		return nil
+	})
+
+	w.Flush()
+
+	return err
+}
+
+// findReleaseAsset returns asset of the given name, or the one built for the platform if name is empty
+func findReleaseAsset(ctx context.Context, client github.ClientInterface, owner, repo, tag, name string, platform *github.Platform) (*github.Asset, error) {
+	t, err := client.DescribeRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		return selectPlatformAsset(owner, repo, tag, t.Release.Assets, *platform)
+	}
+
+	for _, a := range t.Release.Assets {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s/%s@%s : asset %s not found", owner, repo, tag, name)
+}
+
+func init() {
+	RootCmd.AddCommand(lsAssetCmd)
+
+	addPlatformFlags(lsAssetCmd.Flags(), &lsAssetOpts.platformOpts)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+// newAssetServer serves the archives with range request support and counts bytes sent
+func newAssetServer(t *testing.T, archives map[string][]byte) (*fakeClientForGet, *int64) {
+	t.Helper()
+
+	var sent int64
+
+	mux := http.NewServeMux()
+	for name, body := range archives {
+		body := body
+
+		mux.HandleFunc("/"+name, func(w http.ResponseWriter, r *http.Request) {
+			cw := &countingWriter{ResponseWriter: w, n: &sent}
+			http.ServeContent(cw, r, "", time.Time{}, bytes.NewReader(body))
+		})
+	}
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	tag := &github.Tag{Name: "v1.0.0", Release: &github.Release{Assets: []*github.Asset{}}}
+
+	for name, body := range archives {
+		tag.Release.Assets = append(tag.Release.Assets, &github.Asset{Name: name, Size: len(body), URL: ts.URL + "/" + name})
+	}
+
+	return &fakeClientForGet{Tag: tag}, &sent
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	n *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	atomic.AddInt64(w.n, int64(len(p)))
+	return w.ResponseWriter.Write(p)
+}
+
+func newTestAssetArchives(t *testing.T) map[string][]byte {
+	// incompressible large file to check that zip archive is read partially
+	large := make([]byte, 4*1024*1024)
+	rand.New(rand.NewSource(1)).Read(large)
+
+	files := []testArchiveFile{
+		{Name: "tool_1.0.0/LICENSE", Body: "MIT License", Mode: 0644},
+		{Name: "tool_1.0.0/tool", Body: string(large), Mode: 0755},
+		{Name: "tool_1.0.0/README.md", Body: "# tool", Mode: 0644},
+	}
+
+	return map[string][]byte{
+		"tool_1.0.0_linux_amd64.tar.gz": newTestArchive(t, formatTarGzip, files),
+		"tool_1.0.0_windows_amd64.zip":  newTestArchive(t, formatZip, files),
+		"checksums.txt":                 []byte("checksums"),
+	}
+}
+
+func TestRunLsAsset(t *testing.T) {
+	client, sent := newAssetServer(t, newTestAssetArchives(t))
+
+	want := "" +
+		"PATH                    SIZE       MODE\n" +
+		"tool_1.0.0/LICENSE      11         -rw-r--r--\n" +
+		"tool_1.0.0/tool         4194304    -rwxr-xr-x\n" +
+		"tool_1.0.0/README.md    6          -rw-r--r--\n"
+
+	testcases := []struct {
+		args     []string
+		platform *github.Platform
+	}{
+		{
+			args: []string{"owner/tool", "v1.0.0", "tool_1.0.0_linux_amd64.tar.gz"},
+		},
+		{
+			args: []string{"owner/tool", "v1.0.0", "tool_1.0.0_windows_amd64.zip"},
+		},
+		{
+			args:     []string{"owner/tool", "v1.0.0"},
+			platform: &github.Platform{OS: "windows", Arch: "amd64"},
+		},
+	}
+
+	for _, tc := range testcases {
+		atomic.StoreInt64(sent, 0)
+
+		stdout := &bytes.Buffer{}
+
+		if err := RunLsAsset(stdout, &bytes.Buffer{}, tc.args, client, tc.platform); err != nil {
+			t.Errorf("%q: want: no error, got: %s", tc.args, err)
+			continue
+		}
+
+		if stdout.String() != want {
+			t.Errorf("%q: want: %q, got: %q", tc.args, want, stdout.String())
+		}
+
+		if strings.HasSuffix(tc.args[len(tc.args)-1], ".zip") || tc.platform != nil {
+			if n := atomic.LoadInt64(sent); n > 1024*1024 {
+				t.Errorf("want: zip archive read partially, got: %d bytes read", n)
+			}
+		}
+	}
+}
+
+func TestRunLsAsset_error(t *testing.T) {
+	client, _ := newAssetServer(t, newTestAssetArchives(t))
+
+	testcases := []struct {
+		args []string
+		want string
+	}{
+		{
+			args: []string{"owner/tool", "v1.0.0"},
+			want: "Please specify repository <user/name>, tag and asset name.",
+		},
+		{
+			args: []string{"owner/tool", "v1.0.0", "tool.tar.gz"},
+			want: "owner/tool@v1.0.0 : asset tool.tar.gz not found",
+		},
+		{
+			args: []string{"owner/tool", "v1.0.0", "checksums.txt"},
+			want: "Unsupported archive format: checksums.txt (must be tar, tar.gz, tar.xz, tar.bz2 or zip)",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := RunLsAsset(&bytes.Buffer{}, &bytes.Buffer{}, tc.args, client, nil)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestRunCatAsset(t *testing.T) {
+	client, sent := newAssetServer(t, newTestAssetArchives(t))
+
+	testcases := []struct {
+		args     []string
+		platform *github.Platform
+		want     string
+	}{
+		{
+			args: []string{"owner/tool", "v1.0.0", "tool_1.0.0_linux_amd64.tar.gz", "tool_1.0.0/LICENSE"},
+			want: "MIT License",
+		},
+		{
+			args: []string{"owner/tool", "v1.0.0", "tool_1.0.0_windows_amd64.zip", "./tool_1.0.0/README.md"},
+			want: "# tool",
+		},
+		{
+			args:     []string{"owner/tool", "v1.0.0", "tool_1.0.0/LICENSE"},
+			platform: &github.Platform{OS: "linux", Arch: "amd64"},
+			want:     "MIT License",
+		},
+	}
+
+	for _, tc := range testcases {
+		atomic.StoreInt64(sent, 0)
+
+		stdout := &bytes.Buffer{}
+
+		if err := RunCatAsset(stdout, &bytes.Buffer{}, tc.args, client, tc.platform); err != nil {
+			t.Errorf("%q: want: no error, got: %s", tc.args, err)
+			continue
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("%q: want: %q, got: %q", tc.args, tc.want, stdout.String())
+		}
+
+		if strings.HasSuffix(tc.args[2], ".zip") {
+			if n := atomic.LoadInt64(sent); n > 1024*1024 {
+				t.Errorf("want: zip archive read partially, got: %d bytes read", n)
+			}
+		}
+	}
+
+	err := RunCatAsset(&bytes.Buffer{}, &bytes.Buffer{}, []string{"owner/tool", "v1.0.0", "tool_1.0.0_linux_amd64.tar.gz", "LICENSE"}, client, nil)
+	if err == nil {
+		t.Fatalf("want: error, got: nil")
+	}
+
+	if want := "LICENSE not found in tool_1.0.0_linux_amd64.tar.gz"; err.Error() != want {
+		t.Errorf("want: %q, got: %q", want, err.Error())
+	}
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	mirrorReleaseFile = "release.json"
+)
+
+// mirrorCmd represents the mirror command
+var mirrorCmd = &cobra.Command{
+	Use:   "mirror REPOSITORY",
+	Short: "Mirror releases and assets into local directory",
+	Long: `Mirror releases and assets into local directory
+
+Release metadata and assets are stored as OWNER/REPO/TAG/release.json and OWNER/REPO/TAG/ASSET under --dest.
+Assets already mirrored are skipped if their size, and checksum if the release has checksum file, match.
+Draft releases are not mirrored. --since mirrors the given release and newer ones only.
+
+Example:
+
+$ ghrls mirror dtan4/ghrls --dest ./mirror --since v1.0.0
+dtan4/ghrls/v1.0.0/ghrls_1.0.0_linux_amd64.tar.gz
+dtan4/ghrls/v1.0.0/checksums.txt
+dtan4/ghrls/v1.0.0/release.json
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newMirrorStore(mirrorOpts.Dest)
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunMirror(os.Stdout, os.Stderr, args, client, store, mirrorOpts.Since)
+	},
+}
+
+var mirrorOpts = struct {
+	Dest  string
+	Since string
+}{}
+
+// mirrorRelease is release metadata stored as release.json
+// Download counts are excluded not to rewrite metadata on every run
+type mirrorRelease struct {
+	Tag         string               `json:"tag"`
+	Name        string               `json:"name"`
+	Body        string               `json:"body"`
+	Author      string               `json:"author"`
+	Prerelease  bool                 `json:"prerelease"`
+	CreatedAt   time.Time            `json:"created_at"`
+	PublishedAt time.Time            `json:"published_at"`
+	URL         string               `json:"url"`
+	TarballURL  string               `json:"tarball_url,omitempty"`
+	ZipballURL  string               `json:"zipball_url,omitempty"`
+	Assets      []mirrorReleaseAsset `json:"assets"`
+}
+
+type mirrorReleaseAsset struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+	URL         string `json:"url"`
+}
+
+// errStopMirror stops walking releases older than --since
+var errStopMirror = errors.New("stop walking releases")
+
+// RunMirror copies releases newer than or equal to since, or all releases if since is empty, into store
+// Stored object keys are printed
+func RunMirror(stdout, stderr io.Writer, args []string, client github.ClientInterface, store mirrorStore, since string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	if !isSafePathElement(owner) || !isSafePathElement(repo) {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+
+	ctx := context.Background()
+
+	releases := []*github.Tag{}
+	found := false
+
+	// releases are walked newest first
+	err := client.WalkReleases(ctx, owner, repo, func(t *github.Tag) error {
+		if t.Release.Draft {
+			return nil
+		}
+
+		releases = append(releases, t)
+
+		if t.Name == since {
+			found = true
+			return errStopMirror
+		}
+
+		return nil
+	})
+	if err != nil && err != errStopMirror {
+		return err
+	}
+
+	if since != "" && !found {
+		return fmt.Errorf("%s/%s@%s : release not found", owner, repo, since)
+	}
+
+	downloaded, skipped := 0, 0
+
+	// oldest first so that interrupted mirror is resumed from where it stopped
+	for i := len(releases) - 1; i >= 0; i-- {
+		t := releases[i]
+		prefix := path.Join(owner, repo, url.PathEscape(t.Name))
+
+		checksums := map[string][]byte{}
+
+		for _, a := range t.Release.Assets {
+			key := path.Join(prefix, a.Name)
+
+			want, err := mirrorChecksum(ctx, client, owner, repo, t.Release.Assets, a, checksums)
+			if err != nil {
+				return err
+			}
+
+			ok, err := isMirrored(ctx, store, key, int64(a.Size), want)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				skipped++
+				continue
+			}
+
+			if err := mirrorAsset(ctx, client, owner, repo, store, key, a, want); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(stdout, key)
+			downloaded++
+		}
+
+		key := path.Join(prefix, mirrorReleaseFile)
+
+		updated, err := putMirrorRelease(ctx, store, key, t)
+		if err != nil {
+			return err
+		}
+
+		if updated {
+			fmt.Fprintln(stdout, key)
+		}
+	}
+
+	fmt.Fprintf(stderr, "Mirrored %d releases of %s/%s: %d assets downloaded, %d assets skipped\n", len(releases), owner, repo, downloaded, skipped)
+
+	return nil
+}
+
+// mirrorChecksum returns SHA-256 checksum of the asset written in checksum file of the release, or empty string
+// Checksum files are cached in checksums by name
+func mirrorChecksum(ctx context.Context, client github.ClientInterface, owner, repo string, assets []*github.Asset, a *github.Asset, checksums map[string][]byte) (string, error) {
+	c := findChecksumAsset(assets, a.Name)
+	if c == nil || c.Name == a.Name {
+		return "", nil
+	}
+
+	b, ok := checksums[c.Name]
+	if !ok {
+		rc, err := client.OpenReleaseAsset(ctx, owner, repo, c, 0, 0)
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+
+		b, err = ioutil.ReadAll(rc)
+		if err != nil {
+			return "", err
+		}
+
+		checksums[c.Name] = b
+	}
+
+	return parseChecksum(bytes.NewReader(b), a.Name), nil
+}
+
+// isMirrored reports whether the object has the given size and SHA-256 checksum
+// Checksum is not compared if sum is empty
+func isMirrored(ctx context.Context, store mirrorStore, key string, size int64, sum string) (bool, error) {
+	s, err := store.Size(ctx, key)
+	if err != nil {
+		if err == errObjectNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if s != size {
+		return false, nil
+	}
+
+	if sum == "" {
+		return true, nil
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+
+	if _, err := io.Copy(h, r); err != nil {
+		return false, err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) == sum, nil
+}
+
+// mirrorAsset downloads the asset into store, failing if its checksum does not match sum
+func mirrorAsset(ctx context.Context, client github.ClientInterface, owner, repo string, store mirrorStore, key string, a *github.Asset, sum string) error {
+	rc, err := client.OpenReleaseAsset(ctx, owner, repo, a, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return store.Put(ctx, key, &checksumReader{r: rc, h: sha256.New(), name: a.Name, want: sum})
+}
+
+// checksumReader fails at the end of r if SHA-256 checksum of the content does not match want
+type checksumReader struct {
+	r    io.Reader
+	h    hash.Hash
+	name string
+	want string
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+
+	if err == io.EOF && r.want != "" {
+		if got := hex.EncodeToString(r.h.Sum(nil)); got != r.want {
+			return n, fmt.Errorf("Checksum mismatch of %s: want %s, got %s", r.name, r.want, got)
+		}
+	}
+
+	return n, err
+}
+
+// putMirrorRelease stores metadata of the release, and reports whether it is updated
+func putMirrorRelease(ctx context.Context, store mirrorStore, key string, t *github.Tag) (bool, error) {
+	mr := mirrorRelease{
+		Tag:         t.Name,
+		Name:        t.Release.Name,
+		Body:        t.Release.Body,
+		Author:      t.Release.Author,
+		Prerelease:  t.Release.Prerelease,
+		CreatedAt:   t.Release.CreatedAt,
+		PublishedAt: t.Release.PublishedAt,
+		URL:         t.Release.URL,
+		TarballURL:  t.Release.TarballURL,
+		ZipballURL:  t.Release.ZipballURL,
+		Assets:      []mirrorReleaseAsset{},
+	}
+
+	for _, a := range t.Release.Assets {
+		mr.Assets = append(mr.Assets, mirrorReleaseAsset{
+			Name:        a.Name,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+			URL:         a.URL,
+		})
+	}
+
+	b, err := json.MarshalIndent(mr, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	b = append(b, '\n')
+
+	r, err := store.Get(ctx, key)
+	if err == nil {
+		current, err := ioutil.ReadAll(r)
+		r.Close()
+
+		if err != nil {
+			return false, err
+		}
+
+		if bytes.Equal(current, b) {
+			return false, nil
+		}
+	} else if err != errObjectNotFound {
+		return false, err
+	}
+
+	if err := store.Put(ctx, key, bytes.NewReader(b)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func init() {
+	RootCmd.AddCommand(mirrorCmd)
+
+	mirrorCmd.Flags().StringVar(&mirrorOpts.Dest, "dest", "", "Directory to store mirror")
+	mirrorCmd.Flags().StringVar(&mirrorOpts.Since, "since", "", "Mirror only the release of the tag and newer ones")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// errObjectNotFound is returned by mirrorStore when the object does not exist
+var errObjectNotFound = errors.New("object not found")
+
+// mirrorStore is destination of mirror
+// Keys are slash-separated paths, e.g. "owner/repo/v1.0.0/release.json"
+type mirrorStore interface {
+	// Size returns size of the object, or errObjectNotFound
+	Size(ctx context.Context, key string) (int64, error)
+	// Get opens the object, or returns errObjectNotFound
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Put stores content read from r, the object must not be updated if r returns error
+	Put(ctx context.Context, key string, r io.Reader) error
+}
+
+// newMirrorStore returns store for the destination
+// Local directory is the only supported destination now, either path or file:// URL
+func newMirrorStore(dest string) (mirrorStore, error) {
+	if dest == "" {
+		return nil, fmt.Errorf("Please specify --dest.")
+	}
+
+	if strings.Contains(dest, "://") {
+		u, err := url.Parse(dest)
+		if err != nil || u.Scheme != "file" {
+			return nil, fmt.Errorf("Unsupported destination: %s (must be local directory)", dest)
+		}
+
+		dest = u.Path
+	}
+
+	return &dirStore{root: dest}, nil
+}
+
+// dirStore stores objects as files under root directory
+type dirStore struct {
+	root string
+}
+
+func (s *dirStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+func (s *dirStore) Size(ctx context.Context, key string) (int64, error) {
+	fi, err := os.Stat(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, errObjectNotFound
+		}
+		return 0, err
+	}
+
+	return fi.Size(), nil
+}
+
+func (s *dirStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Put writes into temporary file first not to leave broken file
+func (s *dirStore) Put(ctx context.Context, key string, r io.Reader) error {
+	p := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(p), "."+filepath.Base(p)+".")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), p)
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+// newMirrorTestClient returns releases, newest first, whose assets are served by test server
+func newMirrorTestClient(t *testing.T, files map[string]string) fakeClientForExport {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(body))
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	newRelease := func(tag string, draft bool, names ...string) *github.Tag {
+		assets := []*github.Asset{}
+
+		for _, name := range names {
+			assets = append(assets, &github.Asset{
+				ContentType: "application/octet-stream",
+				Name:        name,
+				Size:        len(files[tag+"/"+name]),
+				URL:         ts.URL + "/" + tag + "/" + name,
+			})
+		}
+
+		return &github.Tag{
+			Name: tag,
+			Release: &github.Release{
+				Assets:      assets,
+				Author:      "dtan4",
+				CreatedAt:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+				Draft:       draft,
+				Name:        tag,
+				PublishedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		}
+	}
+
+	return fakeClientForExport{
+		Releases: []*github.Tag{
+			newRelease("v1.2.0", true, "tool.tar.gz"),
+			newRelease("v1.1.0", false, "tool.tar.gz", "checksums.txt"),
+			newRelease("v1.0.0", false, "tool.tar.gz"),
+		},
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRunMirror(t *testing.T) {
+	client := newMirrorTestClient(t, map[string]string{
+		"v1.0.0/tool.tar.gz":   "tool 1.0.0",
+		"v1.1.0/tool.tar.gz":   "tool 1.1.0",
+		"v1.1.0/checksums.txt": sha256Hex("tool 1.1.0") + "  tool.tar.gz\n",
+		"v1.2.0/tool.tar.gz":   "tool 1.2.0",
+	})
+
+	dest := t.TempDir()
+	store := &dirStore{root: dest}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunMirror(stdout, stderr, []string{"owner/tool"}, client, store, ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "" +
+		"owner/tool/v1.0.0/tool.tar.gz\n" +
+		"owner/tool/v1.0.0/release.json\n" +
+		"owner/tool/v1.1.0/tool.tar.gz\n" +
+		"owner/tool/v1.1.0/checksums.txt\n" +
+		"owner/tool/v1.1.0/release.json\n"
+
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	if want := "Mirrored 2 releases of owner/tool: 3 assets downloaded, 0 assets skipped\n"; stderr.String() != want {
+		t.Errorf("stderr want: %q, got: %q", want, stderr.String())
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dest, "owner", "tool", "v1.1.0", "tool.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "tool 1.1.0" {
+		t.Errorf("want: %q, got: %q", "tool 1.1.0", string(b))
+	}
+
+	b, err = ioutil.ReadFile(filepath.Join(dest, "owner", "tool", "v1.1.0", "release.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mr mirrorRelease
+	if err := json.Unmarshal(b, &mr); err != nil {
+		t.Fatal(err)
+	}
+
+	if mr.Tag != "v1.1.0" || len(mr.Assets) != 2 || mr.Assets[0].Size != 10 {
+		t.Errorf("unexpected release.json: %s", string(b))
+	}
+
+	// nothing is copied again
+	stdout.Reset()
+	stderr.Reset()
+
+	if err := RunMirror(stdout, stderr, []string{"owner/tool"}, client, store, ""); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if stdout.String() != "" {
+		t.Errorf("want: nothing copied, got: %q", stdout.String())
+	}
+
+	if want := "Mirrored 2 releases of owner/tool: 0 assets downloaded, 3 assets skipped\n"; stderr.String() != want {
+		t.Errorf("stderr want: %q, got: %q", want, stderr.String())
+	}
+
+	// broken asset of the same size is detected by checksum
+	if err := ioutil.WriteFile(filepath.Join(dest, "owner", "tool", "v1.1.0", "tool.tar.gz"), []byte("tool 9.9.9"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+
+	if err := RunMirror(stdout, &bytes.Buffer{}, []string{"owner/tool"}, client, store, "v1.1.0"); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := "owner/tool/v1.1.0/tool.tar.gz\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunMirror_error(t *testing.T) {
+	client := newMirrorTestClient(t, map[string]string{
+		"v1.0.0/tool.tar.gz":   "tool 1.0.0",
+		"v1.1.0/tool.tar.gz":   "tampered",
+		"v1.1.0/checksums.txt": sha256Hex("tool 1.1.0") + "  tool.tar.gz\n",
+	})
+
+	testcases := []struct {
+		args  []string
+		since string
+		want  string
+	}{
+		{
+			args: []string{"owner"},
+			want: "Invalid repository name: owner",
+		},
+		{
+			args:  []string{"owner/tool"},
+			since: "v0.9.0",
+			want:  "owner/tool@v0.9.0 : release not found",
+		},
+		{
+			args:  []string{"owner/tool"},
+			since: "v1.1.0",
+			want:  "Checksum mismatch of tool.tar.gz: want " + sha256Hex("tool 1.1.0") + ", got " + sha256Hex("tampered"),
+		},
+	}
+
+	for _, tc := range testcases {
+		dest := t.TempDir()
+
+		err := RunMirror(&bytes.Buffer{}, &bytes.Buffer{}, tc.args, client, &dirStore{root: dest}, tc.since)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+
+		// broken asset is not left
+		if _, err := os.Stat(filepath.Join(dest, "owner", "tool", "v1.1.0", "tool.tar.gz")); !os.IsNotExist(err) {
+			t.Errorf("want: no asset, got: %v", err)
+		}
+	}
+}
+
+func TestNewMirrorStore(t *testing.T) {
+	testcases := []struct {
+		dest    string
+		want    string
+		wantErr bool
+	}{
+		{dest: "./mirror", want: "./mirror"},
+		{dest: "file:///var/mirror", want: "/var/mirror"},
+		{dest: "s3://bucket/mirror", wantErr: true},
+		{dest: "", wantErr: true},
+	}
+
+	for _, tc := range testcases {
+		got, err := newMirrorStore(tc.dest)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: want: error, got: nil", tc.dest)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: want: no error, got: %s", tc.dest, err)
+			continue
+		}
+
+		if s, ok := got.(*dirStore); !ok || s.root != tc.want {
+			t.Errorf("%s: want: dirStore at %s, got: %#v", tc.dest, tc.want, got)
+		}
+	}
+}
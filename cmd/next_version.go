@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/dtan4/ghrls/semver"
+	"github.com/spf13/cobra"
+)
+
+// nextVersionCmd represents the next-version command
+var nextVersionCmd = &cobra.Command{
+	Use:   "next-version REPOSITORY",
+	Short: "Print the next version",
+	Long: `Print the next version
+
+The next version is computed from the latest SemVer tag (prereleases are ignored).
+With --bump auto (default), commits since the tag are inspected for Conventional Commits:
+"BREAKING CHANGE" or "!" bumps major, "feat" bumps minor, and others bump patch.
+With --pre, prerelease number is incremented if prerelease of the next version already exists.
+
+Example:
+
+$ ghrls next-version dtan4/ghrls
+v1.3.0
+
+$ ghrls next-version dtan4/ghrls --bump patch --pre rc
+v1.2.1-rc.1
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunNextVersion(os.Stdout, os.Stderr, args, client, nextVersionOpts.Bump, nextVersionOpts.Pre)
+	},
+}
+
+var nextVersionOpts = struct {
+	Bump string
+	Pre  string
+}{}
+
+// bumpLevel represents which part of version is incremented
+type bumpLevel int
+
+const (
+	bumpNone bumpLevel = iota
+	bumpPatch
+	bumpMinor
+	bumpMajor
+)
+
+var (
+	bumpLevels = map[string]bumpLevel{
+		"major": bumpMajor,
+		"minor": bumpMinor,
+		"patch": bumpPatch,
+	}
+
+	bumpNames = map[bumpLevel]string{
+		bumpMajor: "major",
+		bumpMinor: "minor",
+		bumpPatch: "patch",
+	}
+
+	// e.g. "feat(cmd)!: drop flag"
+	conventionalHeaderRe = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?: `)
+	breakingChangeRe     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+	preIdentifierRe      = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+)
+
+// conventionalBump returns bump level required by the given commit message
+func conventionalBump(message string) bumpLevel {
+	if breakingChangeRe.MatchString(message) {
+		return bumpMajor
+	}
+
+	m := conventionalHeaderRe.FindStringSubmatch(strings.SplitN(message, "\n", 2)[0])
+	if m == nil {
+		return bumpNone
+	}
+
+	if m[3] == "!" {
+		return bumpMajor
+	}
+
+	switch strings.ToLower(m[1]) {
+	case "feat":
+		return bumpMinor
+	case "fix", "perf":
+		return bumpPatch
+	}
+
+	return bumpNone
+}
+
+// bumpVersion returns new version whose part of level is incremented
+func bumpVersion(v *semver.Version, level bumpLevel) *semver.Version {
+	next := &semver.Version{
+		Major:  v.Major,
+		Minor:  v.Minor,
+		Patch:  v.Patch,
+		Prefix: v.Prefix,
+	}
+
+	switch level {
+	case bumpMajor:
+		next.Major, next.Minor, next.Patch = next.Major+1, 0, 0
+	case bumpMinor:
+		next.Minor, next.Patch = next.Minor+1, 0
+	case bumpPatch:
+		next.Patch++
+	}
+
+	return next
+}
+
+// nextPrerelease returns prerelease of next like "1.3.0-rc.2", numbered after existing prereleases
+func nextPrerelease(next *semver.Version, pre string, versions []*semver.Version) *semver.Version {
+	n := 0
+
+	for _, v := range versions {
+		if v.Major != next.Major || v.Minor != next.Minor || v.Patch != next.Patch || !strings.HasPrefix(v.Pre, pre+".") {
+			continue
+		}
+
+		if i, err := strconv.Atoi(strings.TrimPrefix(v.Pre, pre+".")); err == nil && i > n {
+			n = i
+		}
+	}
+
+	return &semver.Version{
+		Major:  next.Major,
+		Minor:  next.Minor,
+		Patch:  next.Patch,
+		Pre:    fmt.Sprintf("%s.%d", pre, n+1),
+		Prefix: next.Prefix,
+	}
+}
+
+// RunNextVersion prints the next version of the given repository
+func RunNextVersion(stdout, stderr io.Writer, args []string, client github.ClientInterface, bump, pre string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	level, ok := bumpLevels[bump]
+	if !ok && bump != "auto" {
+		return fmt.Errorf("Invalid bump: %s (must be auto, major, minor or patch)", bump)
+	}
+
+	if pre != "" && !preIdentifierRe.MatchString(pre) {
+		return fmt.Errorf("Invalid prerelease identifier: %s", pre)
+	}
+
+	ctx := context.Background()
+
+	tags, err := client.ListTagsAndReleases(ctx, owner, repo, nil)
+	if err != nil {
+		return err
+	}
+
+	versions := []*semver.Version{}
+
+	var latest *semver.Version
+	var latestTag string
+
+	for _, t := range tags {
+		v, err := semver.Parse(t.Name)
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, v)
+
+		if !v.IsPrerelease() && (latest == nil || v.Compare(latest) > 0) {
+			latest, latestTag = v, t.Name
+		}
+	}
+
+	if latest == nil {
+		if bump == "auto" {
+			return fmt.Errorf("%s/%s has no SemVer tag. Please specify --bump.", owner, repo)
+		}
+
+		latest = &semver.Version{Prefix: "v"}
+	}
+
+	if bump == "auto" {
+		commits, err := client.ListCommits(ctx, owner, repo, latestTag, "HEAD")
+		if err != nil {
+			return err
+		}
+
+		if len(commits) == 0 {
+			return fmt.Errorf("No commits since %s.", latestTag)
+		}
+
+		level = bumpPatch
+
+		for _, c := range commits {
+			if l := conventionalBump(c.Message); l > level {
+				level = l
+			}
+		}
+
+		fmt.Fprintf(stderr, "%d commits since %s require %s bump\n", len(commits), latestTag, bumpNames[level])
+	}
+
+	next := bumpVersion(latest, level)
+
+	if pre != "" {
+		next = nextPrerelease(next, pre, versions)
+	}
+
+	fmt.Fprintln(stdout, next.String())
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(nextVersionCmd)
+
+	nextVersionCmd.Flags().StringVar(&nextVersionOpts.Bump, "bump", "auto", "Part of version to bump: auto, major, minor or patch")
+	nextVersionCmd.Flags().StringVar(&nextVersionOpts.Pre, "pre", "", `Prerelease identifier, e.g. "rc" for v1.3.0-rc.1`)
+}
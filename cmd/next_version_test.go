@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForNextVersion struct {
+	fakeClient
+
+	Tags    []*github.Tag
+	Commits []string
+	from    *string
+}
+
+func (c fakeClientForNextVersion) ListCommits(ctx context.Context, owner, repo, from, to string) ([]*github.Commit, error) {
+	*c.from = from
+
+	commits := []*github.Commit{}
+
+	for _, m := range c.Commits {
+		commits = append(commits, &github.Commit{Message: m})
+	}
+
+	return commits, nil
+}
+
+func (c fakeClientForNextVersion) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *github.ListFilter) ([]*github.Tag, error) {
+	return c.Tags, nil
+}
+
+func nextVersionTestTags(names ...string) []*github.Tag {
+	tags := []*github.Tag{}
+
+	for _, name := range names {
+		tags = append(tags, &github.Tag{Name: name})
+	}
+
+	return tags
+}
+
+func TestConventionalBump(t *testing.T) {
+	testcases := []struct {
+		message string
+		want    bumpLevel
+	}{
+		{message: "feat: add prune command", want: bumpMinor},
+		{message: "feat(cmd): add prune command\n\ncloses #1", want: bumpMinor},
+		{message: "fix: typo", want: bumpPatch},
+		{message: "perf(github): stop pagination early", want: bumpPatch},
+		{message: "docs: update README", want: bumpNone},
+		{message: "Merge pull request #1 from dtan4/feat", want: bumpNone},
+		{message: "refactor!: drop --raw flag", want: bumpMajor},
+		{message: "feat(cmd)!: drop --raw flag", want: bumpMajor},
+		{message: "fix: handle 404\n\nBREAKING CHANGE: exit code changed", want: bumpMajor},
+		{message: "fix: handle 404\n\nBREAKING-CHANGE: exit code changed", want: bumpMajor},
+		{message: "fix: mention BREAKING CHANGE: in docs", want: bumpPatch},
+	}
+
+	for _, tc := range testcases {
+		if got := conventionalBump(tc.message); got != tc.want {
+			t.Errorf("%q: want: %d, got: %d", tc.message, tc.want, got)
+		}
+	}
+}
+
+func TestRunNextVersion(t *testing.T) {
+	testcases := []struct {
+		tags     []string
+		commits  []string
+		bump     string
+		pre      string
+		want     string
+		wantFrom string
+	}{
+		{
+			tags:     []string{"v1.3.0-rc.1", "v1.2.0", "v1.10.0-alpha", "v1.1.0", "latest"},
+			commits:  []string{"fix: a", "feat: b", "docs: c"},
+			bump:     "auto",
+			want:     "v1.3.0\n",
+			wantFrom: "v1.2.0",
+		},
+		{
+			tags:     []string{"1.2.0"},
+			commits:  []string{"docs: a"},
+			bump:     "auto",
+			want:     "1.2.1\n",
+			wantFrom: "1.2.0",
+		},
+		{
+			tags:     []string{"v1.2.0"},
+			commits:  []string{"fix: a", "feat!: b"},
+			bump:     "auto",
+			want:     "v2.0.0\n",
+			wantFrom: "v1.2.0",
+		},
+		{
+			tags:     []string{"v1.3.0-rc.2", "v1.3.0-rc.1", "v1.3.0-beta.5", "v1.2.0"},
+			commits:  []string{"feat: a"},
+			bump:     "auto",
+			pre:      "rc",
+			want:     "v1.3.0-rc.3\n",
+			wantFrom: "v1.2.0",
+		},
+		{
+			tags: []string{"v1.3.0-rc.2", "v1.2.0"},
+			bump: "patch",
+			pre:  "rc",
+			want: "v1.2.1-rc.1\n",
+		},
+		{
+			tags: []string{"v1.2.3"},
+			bump: "major",
+			want: "v2.0.0\n",
+		},
+		{
+			tags: []string{"latest"},
+			bump: "minor",
+			want: "v0.1.0\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		var from string
+		client := fakeClientForNextVersion{Tags: nextVersionTestTags(tc.tags...), Commits: tc.commits, from: &from}
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		if err := RunNextVersion(stdout, stderr, []string{"dtan4/ghrls"}, client, tc.bump, tc.pre); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("tags %q: want: %q, got: %q", tc.tags, tc.want, stdout.String())
+		}
+
+		if from != tc.wantFrom {
+			t.Errorf("commits from want: %q, got: %q", tc.wantFrom, from)
+		}
+	}
+}
+
+func TestRunNextVersion_error(t *testing.T) {
+	testcases := []struct {
+		args    []string
+		tags    []string
+		commits []string
+		bump    string
+		pre     string
+		want    string
+	}{
+		{
+			args: []string{"ghrls"},
+			bump: "auto",
+			want: "Invalid repository name: ghrls",
+		},
+		{
+			args: []string{"dtan4/ghrls"},
+			bump: "huge",
+			want: "Invalid bump: huge (must be auto, major, minor or patch)",
+		},
+		{
+			args: []string{"dtan4/ghrls"},
+			bump: "auto",
+			pre:  "rc.1",
+			want: "Invalid prerelease identifier: rc.1",
+		},
+		{
+			args: []string{"dtan4/ghrls"},
+			tags: []string{"v1.0.0-rc.1"},
+			bump: "auto",
+			want: "dtan4/ghrls has no SemVer tag. Please specify --bump.",
+		},
+		{
+			args: []string{"dtan4/ghrls"},
+			tags: []string{"v1.0.0"},
+			bump: "auto",
+			want: "No commits since v1.0.0.",
+		},
+	}
+
+	for _, tc := range testcases {
+		var from string
+		client := fakeClientForNextVersion{Tags: nextVersionTestTags(tc.tags...), Commits: tc.commits, from: &from}
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunNextVersion(stdout, stderr, tc.args, client, tc.bump, tc.pre)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
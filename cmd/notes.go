@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// notesCmd represents the notes command
+var notesCmd = &cobra.Command{
+	Use:   "notes REPOSITORY",
+	Short: "Generate release notes from merged pull requests",
+	Long: `Generate release notes from merged pull requests
+
+Pull requests merged between --from and --to are grouped into sections by their labels.
+--from defaults to the latest release. Sections and line format can be customized by --template:
+
+{
+  "sections": [
+    {"title": "Breaking Changes", "labels": ["breaking"]},
+    {"title": "Features", "labels": ["feature", "enhancement"]},
+    {"title": "Bug Fixes", "labels": ["bug"]}
+  ],
+  "other": "Other Changes",
+  "exclude_labels": ["skip-changelog"],
+  "item": "{{.Title}} (#{{.Number}}) @{{.Author}}"
+}
+
+Pull request is put into the first section which has any of its labels, or "other" section.
+"item" is Go template of each line, and can refer Number, Title, Author, URL and Labels.
+
+Example:
+
+$ ghrls notes dtan4/ghrls --from v1.2.0 --to HEAD
+## Features
+
+- Add prune command (#42) @dtan4
+
+## Bug Fixes
+
+- Fix pagination of tags (#41) @dtan4
+
+**Full Changelog**: https://github.com/dtan4/ghrls/compare/v1.2.0...HEAD
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		if notesOpts.Generate {
+			tag := notesOpts.Tag
+			// HEAD is not a tag, so --to is used only if it is given explicitly
+			if tag == "" && cmd.Flags().Changed("to") && notesOpts.To != "HEAD" {
+				tag = notesOpts.To
+			}
+
+			return RunNotesGenerate(os.Stdout, os.Stderr, args, client, tag, notesOpts.From)
+		}
+
+		tmpl := defaultNotesTemplate()
+
+		if notesOpts.Template != "" {
+			tmpl, err = loadNotesTemplate(notesOpts.Template)
+			if err != nil {
+				return err
+			}
+		}
+
+		return RunNotes(os.Stdout, os.Stderr, args, client, notesOpts.From, notesOpts.To, tmpl)
+	},
+}
+
+var notesOpts = struct {
+	From     string
+	Generate bool
+	Tag      string
+	Template string
+	To       string
+}{}
+
+// notesTemplate represents how pull requests are rendered into release notes
+type notesTemplate struct {
+	Sections      []notesSection `json:"sections"`
+	Other         string         `json:"other"`
+	ExcludeLabels []string       `json:"exclude_labels"`
+	Item          string         `json:"item"`
+
+	item *template.Template
+}
+
+type notesSection struct {
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+const (
+	defaultNotesItem = "{{.Title}} (#{{.Number}}) @{{.Author}}"
+)
+
+func defaultNotesTemplate() *notesTemplate {
+	t := &notesTemplate{
+		Sections: []notesSection{
+			{Title: "Breaking Changes", Labels: []string{"breaking"}},
+			{Title: "Features", Labels: []string{"feature", "enhancement"}},
+			{Title: "Bug Fixes", Labels: []string{"bug"}},
+		},
+		Other: "Other Changes",
+		Item:  defaultNotesItem,
+	}
+
+	t.item = template.Must(template.New("item").Parse(t.Item))
+
+	return t
+}
+
+// loadNotesTemplate reads notes template from JSON file
+func loadNotesTemplate(filename string) (*notesTemplate, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var t notesTemplate
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("Invalid template %s: %s", filename, err)
+	}
+
+	if t.Item == "" {
+		t.Item = defaultNotesItem
+	}
+
+	t.item, err = template.New("item").Parse(t.Item)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid template %s: %s", filename, err)
+	}
+
+	return &t, nil
+}
+
+// section returns index of section the pull request belongs to
+// len(t.Sections) means "other" section, and -1 means the pull request is excluded
+func (t *notesTemplate) section(pr *github.PullRequest) int {
+	if hasAnyLabel(pr, t.ExcludeLabels) {
+		return -1
+	}
+
+	for i, s := range t.Sections {
+		if hasAnyLabel(pr, s.Labels) {
+			return i
+		}
+	}
+
+	return len(t.Sections)
+}
+
+func hasAnyLabel(pr *github.PullRequest, labels []string) bool {
+	for _, l := range pr.Labels {
+		for _, want := range labels {
+			if strings.EqualFold(l, want) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// render renders release notes in Markdown
+func (t *notesTemplate) render(prs []*github.PullRequest) (string, error) {
+	groups := make([][]*github.PullRequest, len(t.Sections)+1)
+
+	for _, pr := range prs {
+		if i := t.section(pr); i >= 0 {
+			groups[i] = append(groups[i], pr)
+		}
+	}
+
+	titles := []string{}
+	for _, s := range t.Sections {
+		titles = append(titles, s.Title)
+	}
+	titles = append(titles, t.Other)
+
+	var b bytes.Buffer
+
+	for i, group := range groups {
+		// pull requests without section are omitted if "other" is empty
+		if len(group) == 0 || titles[i] == "" {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s\n\n", titles[i])
+
+		for _, pr := range group {
+			var line bytes.Buffer
+
+			if err := t.item.Execute(&line, pr); err != nil {
+				return "", err
+			}
+
+			fmt.Fprintf(&b, "- %s\n", line.String())
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+// RunNotes prints release notes built from pull requests merged between from and to
+// from can be empty to start from the latest release
+func RunNotes(stdout, stderr io.Writer, args []string, client github.ClientInterface, from, to string, tmpl *notesTemplate) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx := context.Background()
+
+	if from == "" {
+		latest, err := client.LatestRelease(ctx, owner, repo)
+		if err != nil {
+			return err
+		}
+
+		if latest == nil {
+			return fmt.Errorf("%s/%s has no release. Please specify --from.", owner, repo)
+		}
+
+		from = latest.Name
+	}
+
+	prs, err := client.ListMergedPullRequests(ctx, owner, repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	notes, err := tmpl.render(prs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(stdout, notes)
+	fmt.Fprintf(stdout, "**Full Changelog**: https://github.com/%s/%s/compare/%s...%s\n", owner, repo, from, to)
+
+	return nil
+}
+
+// RunNotesGenerate prints release notes generated by GitHub
+func RunNotesGenerate(stdout, stderr io.Writer, args []string, client github.ClientInterface, tag, previousTag string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	if tag == "" {
+		return fmt.Errorf("Please specify --tag with --generate.")
+	}
+
+	ctx := context.Background()
+
+	notes, err := client.GenerateReleaseNotes(ctx, owner, repo, tag, previousTag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, notes)
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(notesCmd)
+
+	notesCmd.Flags().StringVar(&notesOpts.From, "from", "", "Tag or commit to start from, exclusive (default: the latest release)")
+	notesCmd.Flags().StringVar(&notesOpts.To, "to", "HEAD", "Tag or commit to end at, inclusive")
+	notesCmd.Flags().StringVar(&notesOpts.Template, "template", "", "JSON file which defines sections and line format")
+	notesCmd.Flags().BoolVar(&notesOpts.Generate, "generate", false, "Use release notes generated by GitHub instead")
+	notesCmd.Flags().StringVar(&notesOpts.Tag, "tag", "", "Tag name of the release to generate notes for with --generate (default: --to unless it is HEAD)")
+}
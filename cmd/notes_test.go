@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForNotes struct {
+	fakeClient
+
+	Latest       *github.Tag
+	PullRequests []*github.PullRequest
+	Notes        string
+	Err          error
+	calls        *[]string
+}
+
+func (c fakeClientForNotes) GenerateReleaseNotes(ctx context.Context, owner, repo, tag, previousTag string) (string, error) {
+	*c.calls = append(*c.calls, "generate "+tag+" "+previousTag)
+
+	return c.Notes, c.Err
+}
+
+func (c fakeClientForNotes) LatestRelease(ctx context.Context, owner, repo string) (*github.Tag, error) {
+	return c.Latest, nil
+}
+
+func (c fakeClientForNotes) ListMergedPullRequests(ctx context.Context, owner, repo, from, to string) ([]*github.PullRequest, error) {
+	*c.calls = append(*c.calls, "compare "+from+"..."+to)
+
+	if c.Err != nil {
+		return []*github.PullRequest{}, c.Err
+	}
+
+	return c.PullRequests, nil
+}
+
+func notesTestPullRequests() []*github.PullRequest {
+	return []*github.PullRequest{
+		{Number: 1, URL: "https://github.com/dtan4/ghrls/pull/1", Title: "Fix pagination", Author: "alice", Labels: []string{"Bug"}},
+		{Number: 2, URL: "https://github.com/dtan4/ghrls/pull/2", Title: "Add prune command", Author: "bob", Labels: []string{"feature"}},
+		{Number: 3, URL: "https://github.com/dtan4/ghrls/pull/3", Title: "Update README", Author: "alice", Labels: []string{}},
+		{Number: 4, URL: "https://github.com/dtan4/ghrls/pull/4", Title: "Drop Go 1.15", Author: "bob", Labels: []string{"breaking", "feature"}},
+		{Number: 5, URL: "https://github.com/dtan4/ghrls/pull/5", Title: "Bump dependencies", Author: "bot", Labels: []string{"skip-changelog"}},
+	}
+}
+
+func TestRunNotes(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "notes.json")
+
+	if err := ioutil.WriteFile(custom, []byte(`{
+  "sections": [{"title": "Changes", "labels": ["feature", "bug"]}],
+  "exclude_labels": ["skip-changelog"],
+  "item": "[#{{.Number}}]({{.URL}}) {{.Title}}"
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	customTmpl, err := loadNotesTemplate(custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		from      string
+		tmpl      *notesTemplate
+		latest    *github.Tag
+		wantCalls []string
+		want      string
+	}{
+		{
+			from:      "v1.0.0",
+			tmpl:      defaultNotesTemplate(),
+			wantCalls: []string{"compare v1.0.0...HEAD"},
+			want: `## Breaking Changes
+
+- Drop Go 1.15 (#4) @bob
+
+## Features
+
+- Add prune command (#2) @bob
+
+## Bug Fixes
+
+- Fix pagination (#1) @alice
+
+## Other Changes
+
+- Update README (#3) @alice
+- Bump dependencies (#5) @bot
+
+**Full Changelog**: https://github.com/dtan4/ghrls/compare/v1.0.0...HEAD
+`,
+		},
+		{
+			tmpl:      customTmpl,
+			latest:    &github.Tag{Name: "v0.9.0"},
+			wantCalls: []string{"compare v0.9.0...HEAD"},
+			want: `## Changes
+
+- [#1](https://github.com/dtan4/ghrls/pull/1) Fix pagination
+- [#2](https://github.com/dtan4/ghrls/pull/2) Add prune command
+- [#4](https://github.com/dtan4/ghrls/pull/4) Drop Go 1.15
+
+**Full Changelog**: https://github.com/dtan4/ghrls/compare/v0.9.0...HEAD
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		calls := []string{}
+		client := fakeClientForNotes{Latest: tc.latest, PullRequests: notesTestPullRequests(), calls: &calls}
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		if err := RunNotes(stdout, stderr, []string{"dtan4/ghrls"}, client, tc.from, "HEAD", tc.tmpl); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if strings.Join(calls, ",") != strings.Join(tc.wantCalls, ",") {
+			t.Errorf("calls want: %q, got: %q", tc.wantCalls, calls)
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("want:\n%s\ngot:\n%s", tc.want, stdout.String())
+		}
+	}
+}
+
+func TestRunNotes_noRelease(t *testing.T) {
+	calls := []string{}
+	client := fakeClientForNotes{calls: &calls}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	err := RunNotes(stdout, stderr, []string{"dtan4/ghrls"}, client, "", "HEAD", defaultNotesTemplate())
+
+	want := "dtan4/ghrls has no release. Please specify --from."
+	if err == nil || err.Error() != want {
+		t.Errorf("want: %q, got: %v", want, err)
+	}
+}
+
+func TestRunNotesGenerate(t *testing.T) {
+	calls := []string{}
+	client := fakeClientForNotes{Notes: "## What's Changed\n* Add prune command by @bob", calls: &calls}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunNotesGenerate(stdout, stderr, []string{"dtan4/ghrls"}, client, "v1.1.0", "v1.0.0"); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := []string{"generate v1.1.0 v1.0.0"}; strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls want: %q, got: %q", want, calls)
+	}
+
+	want := "## What's Changed\n* Add prune command by @bob\n"
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	calls = calls[:0]
+
+	err := RunNotesGenerate(stdout, stderr, []string{"dtan4/ghrls"}, client, "", "v1.0.0")
+	if err == nil || err.Error() != "Please specify --tag with --generate." {
+		t.Errorf("want: --tag error, got: %v", err)
+	}
+
+	if len(calls) != 0 {
+		t.Errorf("want: no API call without tag, got: %q", calls)
+	}
+}
+
+func TestLoadNotesTemplate_invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	testcases := []string{
+		`{"section": []}`,
+		`{"item": "{{.Title"}`,
+		`not json`,
+	}
+
+	for i, content := range testcases {
+		filename := filepath.Join(dir, string(rune('a'+i))+".json")
+
+		if err := ioutil.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := loadNotesTemplate(filename); err == nil {
+			t.Errorf("%s: want: error, got: nil", content)
+		}
+	}
+}
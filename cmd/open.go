@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+// openURL opens the given URL in the default web browser
+// $BROWSER is preferred if set
+func openURL(url string) error {
+	var name string
+	var args []string
+
+	switch {
+	case os.Getenv("BROWSER") != "":
+		name = os.Getenv("BROWSER")
+	case runtime.GOOS != "darwin" && runtime.GOOS != "windows" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "":
+		// e.g. SSH session
+		return fmt.Errorf("no web browser available: no display")
+	case runtime.GOOS == "darwin":
+		name = "open"
+	case runtime.GOOS == "windows":
+		name, args = "rundll32", []string{"url.dll,FileProtocolHandler"}
+	default:
+		name = "xdg-open"
+	}
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("no web browser available: %w", err)
+	}
+
+	cmd := exec.Command(path, append(args, url)...)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+
+	// browser process keeps running after ghrls exits
+	return cmd.Process.Release()
+}
+
+// openOrPrintURL opens the given URL by open, or prints it if web browser is not available
+func openOrPrintURL(stdout io.Writer, url string, open func(string) error) {
+	if err := open(url); err != nil {
+		fmt.Fprintln(stdout, url)
+	}
+}
+
+// tagURL returns web page URL of the given tag
+func tagURL(owner, repo string, t *github.Tag) string {
+	if t.Release != nil && t.Release.URL != "" {
+		return t.Release.URL
+	}
+
+	if t.Release != nil {
+		return fmt.Sprintf("https://github.com/%s/%s/releases/tag/%s", owner, repo, t.Name)
+	}
+
+	return fmt.Sprintf("https://github.com/%s/%s/tree/%s", owner, repo, t.Name)
+}
+
+// releasesURL returns web page URL of the releases of the given repository
+func releasesURL(owner, repo string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/releases", owner, repo)
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// number of repositories whose latest release is retrieved concurrently
+	orgConcurrency = 8
+)
+
+// orgCmd represents the org command
+var orgCmd = &cobra.Command{
+	Use:   "org OWNER",
+	Short: "List the latest release of each repository in organization or user",
+	Long: `List the latest release of each repository in organization or user
+
+Repositories are sorted by published time of the latest release, newest first.
+Archived and forked repositories are excluded by default.
+Repositories whose latest release cannot be retrieved are shown with ERROR.
+
+Example:
+
+$ ghrls org kubernetes --since 2017-01-01
+REPOSITORY               TAG       PUBLISHEDAT                      NAME
+kubernetes/kubernetes    v1.5.2    2017-01-12 16:25:50 +0900 JST    v1.5.2
+kubernetes/kops          1.5.0     2017-01-10 15:20:05 +0900 JST    1.5.0
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		var since time.Time
+
+		if orgOpts.Since != "" {
+			since, _, err = parseDate(orgOpts.Since, tf.location)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch orgOpts.Visibility {
+		case "all":
+			orgOpts.Visibility = ""
+		case "public", "private":
+		default:
+			return fmt.Errorf("Invalid visibility: %s (must be public, private or all)", orgOpts.Visibility)
+		}
+
+		filter := &github.RepositoryFilter{
+			IncludeArchived: orgOpts.IncludeArchived,
+			IncludeForks:    orgOpts.IncludeForks,
+			Visibility:      orgOpts.Visibility,
+			Topics:          orgOpts.Topics,
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return runWithPager(os.Stdout, func(stdout io.Writer) error {
+			return RunOrg(stdout, os.Stderr, args, client, tf, filter, since)
+		})
+	},
+}
+
+var orgOpts = struct {
+	IncludeArchived bool
+	IncludeForks    bool
+	Visibility      string
+	Topics          []string
+	Since           string
+}{}
+
+var (
+	orgHeaders = []string{
+		"REPOSITORY",
+		"TAG",
+		"PUBLISHEDAT",
+		"NAME",
+	}
+)
+
+type repositoryRelease struct {
+	repository *github.Repository
+	tag        *github.Tag
+	// err is set if the latest release could not be retrieved, e.g. 403 on a single repository
+	err error
+}
+
+// RunOrg lists the latest release of each repository owned by the given organization or user
+// Repositories without release published since the given time are omitted unless since is zero
+func RunOrg(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter, filter *github.RepositoryFilter, since time.Time) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify organization or user.")
+	}
+	owner := args[0]
+
+	ctx := context.Background()
+
+	repos, err := client.ListRepositories(ctx, owner, filter)
+	if err != nil {
+		return err
+	}
+
+	rrs := make([]*repositoryRelease, len(repos))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, orgConcurrency)
+
+	for i, r := range repos {
+		wg.Add(1)
+
+		go func(i int, r *github.Repository) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tag, err := client.LatestRelease(ctx, r.Owner, r.Name)
+			rrs[i] = &repositoryRelease{repository: r, tag: tag, err: err}
+		}(i, r)
+	}
+
+	wg.Wait()
+
+	filtered := []*repositoryRelease{}
+
+	for _, rr := range rrs {
+		// failed repositories are always shown, since whether they match cannot be determined
+		if rr.err == nil && !since.IsZero() && (rr.tag == nil || rr.tag.Release.PublishedAt.Before(since)) {
+			continue
+		}
+
+		filtered = append(filtered, rr)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		ti, tj := filtered[i].tag, filtered[j].tag
+
+		if ti == nil || tj == nil {
+			return tj == nil && ti != nil
+		}
+
+		return ti.Release.PublishedAt.After(tj.Release.PublishedAt)
+	})
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, strings.Join(orgHeaders, "\t"))
+
+	for _, rr := range filtered {
+		ss := []string{rr.repository.Owner + "/" + rr.repository.Name}
+
+		if rr.err != nil {
+			ss = append(ss, "ERROR", "", rr.err.Error())
+		} else if rr.tag != nil {
+			ss = append(ss, rr.tag.Name, tf.Format(rr.tag.Release.PublishedAt), rr.tag.Release.Name)
+		} else {
+			ss = append(ss, "", "", "")
+		}
+
+		fmt.Fprintln(w, strings.Join(ss, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(orgCmd)
+
+	orgCmd.Flags().BoolVar(&orgOpts.IncludeArchived, "archived", false, "Include archived repositories")
+	orgCmd.Flags().BoolVar(&orgOpts.IncludeForks, "forks", false, "Include forked repositories")
+	orgCmd.Flags().StringVar(&orgOpts.Visibility, "visibility", "all", "Repository visibility: public, private or all")
+	orgCmd.Flags().StringSliceVar(&orgOpts.Topics, "topic", []string{}, "List only repositories with the topic (can be specified multiple times)")
+	orgCmd.Flags().StringVar(&orgOpts.Since, "since", "", "List only repositories released at or after the date (YYYY-MM-DD or RFC3339)")
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForOrg struct {
+	fakeClient
+
+	Repositories []*github.Repository
+	Tags         map[string]*github.Tag
+	TagErrs      map[string]error
+	Err          error
+}
+
+func (c fakeClientForOrg) LatestRelease(ctx context.Context, owner, repo string) (*github.Tag, error) {
+	if err, ok := c.TagErrs[owner+"/"+repo]; ok {
+		return nil, err
+	}
+
+	return c.Tags[owner+"/"+repo], nil
+}
+
+func (c fakeClientForOrg) ListRepositories(ctx context.Context, owner string, filter *github.RepositoryFilter) ([]*github.Repository, error) {
+	if c.Err != nil {
+		return []*github.Repository{}, c.Err
+	}
+
+	return c.Repositories, nil
+}
+
+func TestRunOrg_success(t *testing.T) {
+	client := fakeClientForOrg{
+		Repositories: []*github.Repository{
+			&github.Repository{Owner: "org", Name: "api"},
+			&github.Repository{Owner: "org", Name: "docs"},
+			&github.Repository{Owner: "org", Name: "web"},
+		},
+		Tags: map[string]*github.Tag{
+			"org/api": &github.Tag{
+				Name: "v1.2.0",
+				Release: &github.Release{
+					Name:        "v1.2.0",
+					PublishedAt: time.Date(2017, 1, 10, 4, 0, 0, 0, time.UTC),
+				},
+			},
+			"org/web": &github.Tag{
+				Name: "v3.0.0",
+				Release: &github.Release{
+					Name:        "Web v3",
+					PublishedAt: time.Date(2017, 1, 12, 4, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		since time.Time
+		want  string
+	}{
+		{
+			want: "" +
+				"REPOSITORY    TAG       PUBLISHEDAT                      NAME\n" +
+				"org/web       v3.0.0    2017-01-12 04:00:00 +0000 UTC    Web v3\n" +
+				"org/api       v1.2.0    2017-01-10 04:00:00 +0000 UTC    v1.2.0\n" +
+				"org/docs                                                 \n",
+		},
+		{
+			since: time.Date(2017, 1, 11, 0, 0, 0, 0, time.UTC),
+			want: "" +
+				"REPOSITORY    TAG       PUBLISHEDAT                      NAME\n" +
+				"org/web       v3.0.0    2017-01-12 04:00:00 +0000 UTC    Web v3\n",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+		if err := RunOrg(stdout, stderr, []string{"org"}, client, NewTimeFormatter(time.UTC, ""), nil, tc.since); err != nil {
+			t.Errorf("want: no error, got: %#v", err)
+		}
+
+		if stdout.String() != tc.want {
+			t.Errorf("stdout want:\n%q\ngot:\n%q", tc.want, stdout.String())
+		}
+	}
+}
+
+func TestRunOrg_releaseError(t *testing.T) {
+	client := fakeClientForOrg{
+		Repositories: []*github.Repository{
+			&github.Repository{Owner: "org", Name: "api"},
+			&github.Repository{Owner: "org", Name: "secret"},
+		},
+		Tags: map[string]*github.Tag{
+			"org/api": &github.Tag{
+				Name: "v1.2.0",
+				Release: &github.Release{
+					Name:        "v1.2.0",
+					PublishedAt: time.Date(2017, 1, 10, 4, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		TagErrs: map[string]error{
+			"org/secret": fmt.Errorf("403 Resource not accessible by integration"),
+		},
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	if err := RunOrg(stdout, stderr, []string{"org"}, client, NewTimeFormatter(time.UTC, ""), nil, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("want: no error, got: %#v", err)
+	}
+
+	want := "" +
+		"REPOSITORY    TAG       PUBLISHEDAT                      NAME\n" +
+		"org/api       v1.2.0    2017-01-10 04:00:00 +0000 UTC    v1.2.0\n" +
+		"org/secret    ERROR                                      403 Resource not accessible by integration\n"
+
+	if stdout.String() != want {
+		t.Errorf("stdout want:\n%q\ngot:\n%q", want, stdout.String())
+	}
+}
+
+func TestRunOrg_error(t *testing.T) {
+	testcases := []struct {
+		args []string
+		err  error
+		want string
+	}{
+		{
+			args: []string{},
+			want: "Please specify organization or user.",
+		},
+		{
+			args: []string{"org"},
+			err:  &github.Error{Kind: github.ErrRepoNotFound, Owner: "org"},
+			want: "org: user or organization not found",
+		},
+		{
+			args: []string{"org"},
+			err:  fmt.Errorf("unexpected error"),
+			want: "unexpected error",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+		client := fakeClientForOrg{
+			Err: tc.err,
+		}
+
+		err := RunOrg(stdout, stderr, tc.args, client, NewTimeFormatter(time.UTC, ""), nil, time.Time{})
+
+		if err == nil {
+			t.Error("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("error want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
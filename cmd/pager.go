@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	defaultPager = "less -FRX"
+)
+
+// pagerCommand returns pager command line from GHRLS_PAGER or PAGER
+// Empty string means paging is disabled
+func pagerCommand() string {
+	if v, ok := os.LookupEnv("GHRLS_PAGER"); ok {
+		return v
+	}
+
+	if v, ok := os.LookupEnv("PAGER"); ok {
+		return v
+	}
+
+	return defaultPager
+}
+
+// startPager starts pager which writes to stdout, and returns writer to pipe output into
+// stdout is returned as is if stdout is not terminal, paging is disabled or pager is not found
+// wait must be called to close the pipe and wait for the pager to exit, and returns error if the pager failed
+func startPager(stdout *os.File) (w io.Writer, wait func() error) {
+	noop := func() error { return nil }
+
+	if rootOpts.NoPager || !isTerminal(stdout) {
+		return stdout, noop
+	}
+
+	args := strings.Fields(pagerCommand())
+	if len(args) == 0 || args[0] == "cat" {
+		return stdout, noop
+	}
+
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return stdout, noop
+	}
+
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+
+	pipe, err := cmd.StdinPipe()
+	if err != nil {
+		return stdout, noop
+	}
+
+	if err := cmd.Start(); err != nil {
+		return stdout, noop
+	}
+
+	return pipe, func() error {
+		pipe.Close()
+
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("Pager %s failed: %s", args[0], err)
+		}
+
+		return nil
+	}
+}
+
+// runWithPager calls fn with writer piped into pager, and returns error of fn, or the pager if fn succeeded
+func runWithPager(stdout *os.File, fn func(w io.Writer) error) error {
+	w, wait := startPager(stdout)
+
+	err := fn(w)
+
+	if werr := wait(); err == nil {
+		err = werr
+	}
+
+	return err
+}
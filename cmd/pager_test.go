@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func setenv(t *testing.T, key string, value *string) {
+	t.Helper()
+
+	orig, ok := os.LookupEnv(key)
+
+	if value == nil {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, *value)
+	}
+
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, orig)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestPagerCommand(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	testcases := []struct {
+		ghrlsPager *string
+		pager      *string
+		want       string
+	}{
+		{
+			want: "less -FRX",
+		},
+		{
+			pager: str("more"),
+			want:  "more",
+		},
+		{
+			ghrlsPager: str("less -R"),
+			pager:      str("more"),
+			want:       "less -R",
+		},
+		{
+			ghrlsPager: str(""),
+			pager:      str("more"),
+			want:       "",
+		},
+	}
+
+	for _, tc := range testcases {
+		setenv(t, "GHRLS_PAGER", tc.ghrlsPager)
+		setenv(t, "PAGER", tc.pager)
+
+		if got := pagerCommand(); got != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, got)
+		}
+	}
+}
+
+func TestStartPager_notTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "ghrls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w, wait := startPager(f)
+
+	if w != f {
+		t.Errorf("want: output written to the file directly, got: %#v", w)
+	}
+
+	if err := wait(); err != nil {
+		t.Errorf("want: no error, got: %s", err)
+	}
+}
+
+func TestRunWithPager_notTerminal(t *testing.T) {
+	f, err := ioutil.TempFile("", "ghrls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	want := fmt.Errorf("failed")
+
+	err = runWithPager(f, func(w io.Writer) error {
+		if w != f {
+			t.Errorf("want: output written to the file directly, got: %#v", w)
+		}
+
+		return want
+	})
+
+	if err != want {
+		t.Errorf("want: %v, got: %v", want, err)
+	}
+}
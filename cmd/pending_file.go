@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// pendingFile is temporary file next to filename, which replaces filename on Commit
+// Abort removes it, so that failure leaves neither partial file nor truncated existing one
+type pendingFile struct {
+	*os.File
+	filename string
+}
+
+func createPendingFile(filename string) (*pendingFile, error) {
+	f, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingFile{
+		File:     f,
+		filename: filename,
+	}, nil
+}
+
+// Commit closes the temporary file, unless it is already closed, and renames it to filename
+func (f *pendingFile) Commit() error {
+	if err := f.finish(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(f.Name(), f.filename); err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write %s: %w", f.filename, err)
+	}
+
+	return nil
+}
+
+// CommitNew is Commit which fails instead of replacing filename if it already exists
+// The temporary file is hard linked to filename, so that the existence check and the creation are atomic
+func (f *pendingFile) CommitNew() error {
+	if err := f.finish(); err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := os.Link(f.Name(), f.filename); err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists.", f.filename)
+		}
+		return fmt.Errorf("failed to write %s: %w", f.filename, err)
+	}
+
+	return nil
+}
+
+// finish closes the temporary file, unless it is already closed, and makes it readable by others
+// The temporary file is removed on failure
+func (f *pendingFile) finish() error {
+	if err := f.File.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		os.Remove(f.Name())
+		return err
+	}
+
+	// TempFile creates file with 0600
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Abort closes and removes the temporary file
+func (f *pendingFile) Abort() error {
+	f.File.Close()
+
+	return os.Remove(f.Name())
+}
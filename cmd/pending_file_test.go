@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestPendingFile_CommitNew(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "asset")
+
+	f, err := createPendingFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.WriteString("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	// created by someone else after the existence check
+	if err := ioutil.WriteFile(filename, []byte("existing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.CommitNew(); err == nil || err.Error() != filename+" already exists." {
+		t.Errorf("want: already exists error, got: %v", err)
+	}
+
+	if b, _ := ioutil.ReadFile(filename); string(b) != "existing" {
+		t.Errorf("existing file must not be overwritten, got: %q", string(b))
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("temporary file must be removed, got: %d files", len(entries))
+	}
+
+	f, err = createPendingFile(filepath.Join(dir, "other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.WriteString("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.CommitNew(); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "other")); string(b) != "new" {
+		t.Errorf("content want: %q, got: %q", "new", string(b))
+	}
+}
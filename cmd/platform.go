@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/pflag"
+)
+
+type platformOpts struct {
+	OS   string
+	Arch string
+	Auto bool
+}
+
+// addPlatformFlags registers the flags to select asset by platform
+func addPlatformFlags(flags *pflag.FlagSet, opts *platformOpts) {
+	flags.StringVar(&opts.OS, "os", "", "Select asset built for the OS (e.g. linux, darwin, windows)")
+	flags.StringVar(&opts.Arch, "arch", "", "Select asset built for the architecture (e.g. amd64, arm64)")
+	flags.BoolVar(&opts.Auto, "auto", false, "Select asset built for the current OS and architecture")
+}
+
+// newPlatform builds platform from the flags, OS or architecture not specified defaults to the current one
+// nil is returned if none of --os, --arch and --auto is specified
+func newPlatform(opts *platformOpts) (*github.Platform, error) {
+	if opts.OS == "" && opts.Arch == "" && !opts.Auto {
+		return nil, nil
+	}
+
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+
+	if opts.OS != "" {
+		goos = opts.OS
+	}
+
+	if opts.Arch != "" {
+		goarch = opts.Arch
+	}
+
+	p, err := github.NewPlatform(goos, goarch)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid platform: %s/%s", goos, goarch)
+	}
+
+	return &p, nil
+}
+
+// selectPlatformAsset picks asset built for the platform from the release of the given tag
+func selectPlatformAsset(owner, repo, tag string, assets []*github.Asset, p github.Platform) (*github.Asset, error) {
+	a, err := github.SelectAsset(assets, p)
+	if err != nil {
+		var serr *github.AssetSelectionError
+		if !errors.As(err, &serr) {
+			return nil, err
+		}
+
+		if errors.Is(err, github.ErrAmbiguousAsset) {
+			return nil, fmt.Errorf("Multiple assets of %s/%s@%s match %s. Please specify asset name:\n  %s", owner, repo, tag, p, strings.Join(serr.Candidates, "\n  "))
+		}
+
+		if len(serr.Candidates) == 0 {
+			return nil, fmt.Errorf("%s/%s@%s has no asset.", owner, repo, tag)
+		}
+
+		return nil, fmt.Errorf("No asset of %s/%s@%s matches %s. Available assets:\n  %s", owner, repo, tag, p, strings.Join(serr.Candidates, "\n  "))
+	}
+
+	return a, nil
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune REPOSITORY",
+	Short: "Delete old releases",
+	Long: `Delete old releases
+
+Releases matching the policy are printed first, and deleted only if --yes is given.
+The newest --keep-last releases are always kept. Draft releases are never deleted.
+
+Example:
+
+$ ghrls prune dtan4/ghrls --keep-last 2 --older-than 180d --only-prereleases
+TAG                     TYPE          CREATEDAT                        NAME
+v1.0.0-nightly.0103     PRERELEASE    2020-01-03 09:00:00 +0900 JST    nightly
+v1.0.0-nightly.0102     PRERELEASE    2020-01-02 09:00:00 +0900 JST    nightly
+v1.0.0-nightly.0101     PRERELEASE    2020-01-01 09:00:00 +0900 JST    nightly
+
+3 of 5 releases will be deleted. Run with --yes to delete them.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		policy := &prunePolicy{
+			KeepLast:        pruneOpts.KeepLast,
+			OnlyPrereleases: pruneOpts.OnlyPrereleases,
+			DeleteTags:      pruneOpts.DeleteTags,
+		}
+
+		if pruneOpts.OlderThan != "" {
+			age, err := parseAge(pruneOpts.OlderThan)
+			if err != nil {
+				return err
+			}
+
+			policy.Before = time.Now().Add(-age)
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunPrune(os.Stdout, os.Stderr, args, client, tf, policy, pruneOpts.Yes && !pruneOpts.DryRun)
+	},
+}
+
+var pruneOpts = struct {
+	DeleteTags      bool
+	DryRun          bool
+	KeepLast        int
+	OlderThan       string
+	OnlyPrereleases bool
+	Yes             bool
+}{}
+
+// prunePolicy represents which releases are deleted by prune command
+type prunePolicy struct {
+	// DeleteTags deletes tags of the releases too
+	DeleteTags bool
+	// KeepLast is the number of the newest releases which are always kept
+	KeepLast int
+	// Before restricts deletion to releases created before it, unless zero
+	Before time.Time
+	// OnlyPrereleases restricts deletion to prereleases
+	// The newest KeepLast prereleases are kept in this case
+	OnlyPrereleases bool
+}
+
+// selectReleases returns releases to delete in the given releases
+func (p *prunePolicy) selectReleases(tags []*github.Tag) []*github.Tag {
+	candidates := []*github.Tag{}
+
+	for _, t := range tags {
+		if t.Release == nil || t.Release.Draft {
+			continue
+		}
+
+		if p.OnlyPrereleases && !t.Release.Prerelease {
+			continue
+		}
+
+		candidates = append(candidates, t)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Release.CreatedAt.After(candidates[j].Release.CreatedAt)
+	})
+
+	selected := []*github.Tag{}
+
+	for i, t := range candidates {
+		if i < p.KeepLast {
+			continue
+		}
+
+		if !p.Before.IsZero() && !t.Release.CreatedAt.Before(p.Before) {
+			continue
+		}
+
+		selected = append(selected, t)
+	}
+
+	return selected
+}
+
+// RunPrune prints releases to delete, and deletes them if execute is true
+func RunPrune(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter, policy *prunePolicy, execute bool) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	if policy.KeepLast <= 0 && policy.Before.IsZero() {
+		// refuse to delete all releases
+		return fmt.Errorf("Please specify --keep-last and/or --older-than.")
+	}
+
+	ctx := context.Background()
+
+	tags, err := client.ListTagsAndReleases(ctx, owner, repo, &github.ListFilter{
+		Type: github.TagTypeRelease,
+	})
+	if err != nil {
+		return err
+	}
+
+	selected := policy.selectReleases(tags)
+
+	if len(selected) == 0 {
+		fmt.Fprintln(stdout, "No releases to delete.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, t := range selected {
+		typ := "RELEASE"
+		if t.Release.Prerelease {
+			typ = "PRERELEASE"
+		}
+
+		fmt.Fprintln(w, strings.Join([]string{t.Name, typ, tf.Format(t.Release.CreatedAt), t.Release.Name}, "\t"))
+	}
+
+	w.Flush()
+
+	target := "releases"
+	if policy.DeleteTags {
+		target = "releases and their tags"
+	}
+
+	fmt.Fprintln(stdout, "")
+
+	if !execute {
+		fmt.Fprintf(stdout, "%d of %d %s will be deleted. Run with --yes to delete them.\n", len(selected), len(tags), target)
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "Deleting %d of %d %s...\n", len(selected), len(tags), target)
+
+	for _, t := range selected {
+		if err := client.DeleteRelease(ctx, owner, repo, t.Name, policy.DeleteTags); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", t.Name, err)
+		}
+
+		fmt.Fprintln(stdout, "Deleted "+t.Name)
+	}
+
+	return nil
+}
+
+// parseAge parses positive duration like "180d", "4w" or "36h"
+// Zero age is rejected, as it would select every release
+func parseAge(s string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+
+	for suffix, unit := range units {
+		if !strings.HasSuffix(s, suffix) {
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("Invalid age: %s", s)
+		}
+
+		return time.Duration(n) * unit, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("Invalid age: %s", s)
+	}
+
+	return d, nil
+}
+
+func init() {
+	RootCmd.AddCommand(pruneCmd)
+
+	pruneCmd.Flags().IntVar(&pruneOpts.KeepLast, "keep-last", 0, "Number of the newest releases to keep")
+	pruneCmd.Flags().StringVar(&pruneOpts.OlderThan, "older-than", "", "Delete only releases older than this (e.g. 180d, 4w, 36h)")
+	pruneCmd.Flags().BoolVar(&pruneOpts.OnlyPrereleases, "only-prereleases", false, "Delete only prereleases")
+	pruneCmd.Flags().BoolVar(&pruneOpts.DeleteTags, "delete-tags", false, "Delete tags of the releases too")
+	pruneCmd.Flags().BoolVar(&pruneOpts.DryRun, "dry-run", false, "Print releases to delete without deleting them, even if --yes is given")
+	pruneCmd.Flags().BoolVarP(&pruneOpts.Yes, "yes", "y", false, "Delete releases without dry run")
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForPrune struct {
+	fakeClient
+
+	Tags    []*github.Tag
+	Err     error
+	deleted *[]string
+}
+
+func (c fakeClientForPrune) DeleteRelease(ctx context.Context, owner, repo, tag string, deleteTag bool) error {
+	if c.Err != nil {
+		return c.Err
+	}
+
+	*c.deleted = append(*c.deleted, fmt.Sprintf("%s deleteTag=%t", tag, deleteTag))
+
+	return nil
+}
+
+func (c fakeClientForPrune) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *github.ListFilter) ([]*github.Tag, error) {
+	return c.Tags, nil
+}
+
+func pruneTestTags() []*github.Tag {
+	release := func(name string, day int, prerelease, draft bool) *github.Tag {
+		return &github.Tag{
+			Name: name,
+			Release: &github.Release{
+				CreatedAt:  time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC),
+				Draft:      draft,
+				Name:       name,
+				Prerelease: prerelease,
+			},
+		}
+	}
+
+	return []*github.Tag{
+		release("v1.1.0-rc.1", 6, true, true),
+		release("v1.0.0", 5, false, false),
+		release("v1.0.0-rc.2", 4, true, false),
+		release("v1.0.0-rc.1", 3, true, false),
+		release("v0.9.0", 2, false, false),
+		release("v0.9.0-rc.1", 1, true, false),
+	}
+}
+
+func TestPrunePolicySelectReleases(t *testing.T) {
+	testcases := []struct {
+		policy *prunePolicy
+		want   []string
+	}{
+		{
+			policy: &prunePolicy{KeepLast: 2},
+			want:   []string{"v1.0.0-rc.1", "v0.9.0", "v0.9.0-rc.1"},
+		},
+		{
+			policy: &prunePolicy{Before: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)},
+			want:   []string{"v0.9.0", "v0.9.0-rc.1"},
+		},
+		{
+			policy: &prunePolicy{KeepLast: 1, OnlyPrereleases: true},
+			want:   []string{"v1.0.0-rc.1", "v0.9.0-rc.1"},
+		},
+		{
+			policy: &prunePolicy{KeepLast: 4, Before: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)},
+			want:   []string{"v0.9.0-rc.1"},
+		},
+		{
+			policy: &prunePolicy{KeepLast: 10},
+			want:   []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		got := []string{}
+
+		for _, t := range tc.policy.selectReleases(pruneTestTags()) {
+			got = append(got, t.Name)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("policy %+v: want: %q, got: %q", tc.policy, tc.want, got)
+		}
+	}
+}
+
+func TestRunPrune_dryRun(t *testing.T) {
+	deleted := []string{}
+	client := fakeClientForPrune{Tags: pruneTestTags(), deleted: &deleted}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunPrune(stdout, stderr, []string{"dtan4/ghrls"}, client, NewTimeFormatter(time.UTC, "rfc3339"), &prunePolicy{KeepLast: 1, OnlyPrereleases: true}, false); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := `TAG            TYPE          CREATEDAT               NAME
+v1.0.0-rc.1    PRERELEASE    2020-01-03T00:00:00Z    v1.0.0-rc.1
+v0.9.0-rc.1    PRERELEASE    2020-01-01T00:00:00Z    v0.9.0-rc.1
+
+2 of 6 releases will be deleted. Run with --yes to delete them.
+`
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	if len(deleted) > 0 {
+		t.Errorf("want: nothing deleted, got: %q", deleted)
+	}
+}
+
+func TestRunPrune_execute(t *testing.T) {
+	deleted := []string{}
+	client := fakeClientForPrune{Tags: pruneTestTags(), deleted: &deleted}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunPrune(stdout, stderr, []string{"dtan4/ghrls"}, client, NewTimeFormatter(time.UTC, "rfc3339"), &prunePolicy{KeepLast: 4, DeleteTags: true}, true); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	wantDeleted := []string{"v0.9.0-rc.1 deleteTag=true"}
+	if !reflect.DeepEqual(deleted, wantDeleted) {
+		t.Errorf("want: %q, got: %q", wantDeleted, deleted)
+	}
+
+	want := `TAG            TYPE          CREATEDAT               NAME
+v0.9.0-rc.1    PRERELEASE    2020-01-01T00:00:00Z    v0.9.0-rc.1
+
+Deleting 1 of 6 releases and their tags...
+Deleted v0.9.0-rc.1
+`
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunPrune_error(t *testing.T) {
+	deleted := []string{}
+
+	testcases := []struct {
+		args   []string
+		client github.ClientInterface
+		policy *prunePolicy
+		want   string
+	}{
+		{
+			args:   []string{},
+			client: fakeClientForPrune{deleted: &deleted},
+			policy: &prunePolicy{KeepLast: 1},
+			want:   "Please specify repository <user/name>.",
+		},
+		{
+			args:   []string{"ghrls"},
+			client: fakeClientForPrune{deleted: &deleted},
+			policy: &prunePolicy{KeepLast: 1},
+			want:   "Invalid repository name: ghrls",
+		},
+		{
+			args:   []string{"dtan4/ghrls"},
+			client: fakeClientForPrune{deleted: &deleted},
+			policy: &prunePolicy{OnlyPrereleases: true},
+			want:   "Please specify --keep-last and/or --older-than.",
+		},
+		{
+			args:   []string{"dtan4/ghrls"},
+			client: fakeClientForPrune{Tags: pruneTestTags(), Err: fmt.Errorf("403 Forbidden"), deleted: &deleted},
+			policy: &prunePolicy{KeepLast: 4},
+			want:   "failed to delete v0.9.0-rc.1: 403 Forbidden",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunPrune(stdout, stderr, tc.args, tc.client, NewTimeFormatter(time.UTC, "default"), tc.policy, true)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	testcases := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{s: "180d", want: 180 * 24 * time.Hour},
+		{s: "4w", want: 28 * 24 * time.Hour},
+		{s: "36h", want: 36 * time.Hour},
+		{s: "1h30m", want: 90 * time.Minute},
+		{s: "d", wantErr: true},
+		{s: "-1d", wantErr: true},
+		{s: "0d", wantErr: true},
+		{s: "0w", wantErr: true},
+		{s: "0s", wantErr: true},
+		{s: "180", wantErr: true},
+	}
+
+	for _, tc := range testcases {
+		got, err := parseAge(tc.s)
+
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s: want: error, got: nil", tc.s)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: want: no error, got: %s", tc.s, err)
+			continue
+		}
+
+		if got != tc.want {
+			t.Errorf("%s: want: %s, got: %s", tc.s, tc.want, got)
+		}
+	}
+}
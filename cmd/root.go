@@ -1,22 +1,61 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
+	"strconv"
 
+	"github.com/dtan4/ghrls/github"
 	"github.com/spf13/cobra"
+	"golang.org/x/oauth2"
 )
 
+const (
+	// file to save GitHub API traffic with GHRLS_RECORD=1
+	defaultRecordingFile = "ghrls-recording.json"
+)
+
+// exit codes, so that scripts can tell why ghrls failed
+const (
+	exitCodeError           = 1
+	exitCodeUnauthorized    = 3
+	exitCodeRepoNotFound    = 4
+	exitCodeReleaseNotFound = 5
+	exitCodeTagNotFound     = 6
+	exitCodeRateLimited     = 7
+)
+
+const exitStatusHelp = `Exit status:
+  0  Success
+  1  Other errors, including invalid usage
+  3  GitHub API authentication failed, or the token lacks permission
+  4  Repository (or user / organization) not found
+  5  Release not found
+  6  Tag not found
+  7  GitHub API rate limit exceeded`
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	Use:           "ghrls",
 	Short:         "List & Describe GitHub Releases",
+	Long:          "List & Describe GitHub Releases\n\n" + exitStatusHelp,
+	// runs only after the command succeeded
+	PersistentPostRun: recordRepositoryHook,
 }
 
 var rootOpts = struct {
-	GitHubToken string
+	GitHubToken    string
+	AppID          int64
+	InstallationID int64
+	PrivateKeyFile string
+	Timezone       string
+	TimeFormat     string
+	NoPager        bool
 }{}
 
 // Execute adds all child commands to the root command sets flags appropriately.
@@ -24,12 +63,37 @@ var rootOpts = struct {
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
+// exitCode returns exit code for the error
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, github.ErrUnauthorized):
+		return exitCodeUnauthorized
+	case errors.Is(err, github.ErrRepoNotFound):
+		return exitCodeRepoNotFound
+	case errors.Is(err, github.ErrReleaseNotFound):
+		return exitCodeReleaseNotFound
+	case errors.Is(err, github.ErrTagNotFound):
+		return exitCodeTagNotFound
+	case errors.Is(err, github.ErrRateLimited):
+		return exitCodeRateLimited
+	}
+
+	return exitCodeError
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	RootCmd.PersistentFlags().Int64Var(&rootOpts.AppID, "app-id", 0, "GitHub App ID to authenticate as (env: GITHUB_APP_ID)")
+	RootCmd.PersistentFlags().Int64Var(&rootOpts.InstallationID, "installation-id", 0, "GitHub App installation ID (env: GITHUB_APP_INSTALLATION_ID)")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.PrivateKeyFile, "private-key-file", "", "Path to GitHub App private key (env: GITHUB_APP_PRIVATE_KEY_FILE)")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.Timezone, "timezone", "", "Timezone to print timestamps in, IANA name (e.g. Asia/Tokyo) or UTC (default: local timezone)")
+	RootCmd.PersistentFlags().BoolVar(&rootOpts.NoPager, "no-pager", false, "Do not pipe output into pager (env: GHRLS_PAGER, PAGER)")
+	RootCmd.PersistentFlags().StringVar(&rootOpts.TimeFormat, "time-format", timeFormatDefault, "Timestamp format: default, rfc3339, relative, unix or Go time layout")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -37,4 +101,88 @@ func initConfig() {
 	if os.Getenv("GITHUB_TOKEN") != "" {
 		rootOpts.GitHubToken = os.Getenv("GITHUB_TOKEN")
 	}
+
+	if rootOpts.AppID == 0 {
+		if v, err := strconv.ParseInt(os.Getenv("GITHUB_APP_ID"), 10, 64); err == nil {
+			rootOpts.AppID = v
+		}
+	}
+
+	if rootOpts.InstallationID == 0 {
+		if v, err := strconv.ParseInt(os.Getenv("GITHUB_APP_INSTALLATION_ID"), 10, 64); err == nil {
+			rootOpts.InstallationID = v
+		}
+	}
+
+	if rootOpts.PrivateKeyFile == "" {
+		rootOpts.PrivateKeyFile = os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE")
+	}
+}
+
+// newClient creates GitHub API client from the global options
+// GitHub App authentication takes precedence over GITHUB_TOKEN
+func newClient() (*github.Client, error) {
+	transport, err := newTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	if rootOpts.AppID == 0 && rootOpts.InstallationID == 0 && rootOpts.PrivateKeyFile == "" {
+		var ts oauth2.TokenSource
+
+		if rootOpts.GitHubToken != "" {
+			ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: rootOpts.GitHubToken})
+		}
+
+		return github.NewClientWithTransport(ts, transport), nil
+	}
+
+	if rootOpts.AppID == 0 || rootOpts.InstallationID == 0 || rootOpts.PrivateKeyFile == "" {
+		return nil, fmt.Errorf("--app-id, --installation-id and --private-key-file must be specified together")
+	}
+
+	key, err := ioutil.ReadFile(rootOpts.PrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	ts, err := github.NewAppTokenSource(rootOpts.AppID, rootOpts.InstallationID, key, "", transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClientWithTransport(ts, transport), nil
+}
+
+// newTransport returns transport to record or replay GitHub API traffic, or nil for the default one
+// GHRLS_RECORD=1 saves requests and responses into ghrls-recording.json, or the file GHRLS_RECORD names,
+// and GHRLS_REPLAY=FILE returns the saved responses without network access, e.g. to reproduce bug report
+func newTransport() (http.RoundTripper, error) {
+	if filename := os.Getenv("GHRLS_REPLAY"); filename != "" {
+		r, err := github.NewReplayer(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load GHRLS_REPLAY: %w", err)
+		}
+
+		return r, nil
+	}
+
+	switch v := os.Getenv("GHRLS_RECORD"); v {
+	case "", "0":
+		return nil, nil
+	case "1":
+		return github.NewRecorder(defaultRecordingFile, nil), nil
+	default:
+		return github.NewRecorder(v, nil), nil
+	}
+}
+
+// newTimeFormatter creates TimeFormatter from the global options
+func newTimeFormatter() (*TimeFormatter, error) {
+	loc, err := LoadLocation(rootOpts.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTimeFormatter(loc, rootOpts.TimeFormat), nil
 }
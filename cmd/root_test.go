@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func TestExitCode(t *testing.T) {
+	testcases := []struct {
+		err  error
+		want int
+	}{
+		{
+			err:  errors.New("unexpected error"),
+			want: 1,
+		},
+		{
+			err:  &github.Error{Kind: github.ErrUnauthorized, StatusCode: 401},
+			want: 3,
+		},
+		{
+			err:  &github.Error{Kind: github.ErrRepoNotFound, Owner: "owner", Repo: "repo"},
+			want: 4,
+		},
+		{
+			err:  fmt.Errorf("failed to mirror: %w", &github.Error{Kind: github.ErrReleaseNotFound, Owner: "owner", Repo: "repo", Tag: "v1.0.0"}),
+			want: 5,
+		},
+		{
+			err:  &github.Error{Kind: github.ErrTagNotFound, Owner: "owner", Repo: "repo", Tag: "v1.0.0"},
+			want: 6,
+		},
+		{
+			err:  &github.Error{Kind: github.ErrRateLimited},
+			want: 7,
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := exitCode(tc.err); got != tc.want {
+			t.Errorf("%s: want: %d, got: %d", tc.err, tc.want, got)
+		}
+
+		// every exit code is documented in --help
+		if want := fmt.Sprintf("\n  %d  ", tc.want); !strings.Contains(exitStatusHelp, want) {
+			t.Errorf("want: exit code %d in help, got: %q", tc.want, exitStatusHelp)
+		}
+	}
+}
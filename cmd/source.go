@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// sourceCmd represents the source command
+var sourceCmd = &cobra.Command{
+	Use:   "source REPOSITORY TAG",
+	Short: "Download source code archive of tag",
+	Long: `Download source code archive of tag
+
+Any tag can be downloaded whether it has release or not. The archive is saved as REPO-TAG.tar.gz or REPO-TAG.zip
+unless --out is given, and its SHA-256 checksum is printed in sha256sum format. --out - writes the archive to stdout.
+
+Example:
+
+$ ghrls source dtan4/ghrls v1.1.0
+4f7a1c0f0b5f3a0e2b9d6c8e1a7b3d5f9c2e4a6b8d0f1e3c5a7b9d2f4e6a8c0b  ghrls-v1.1.0.tar.gz
+
+$ ghrls source dtan4/ghrls v1.1.0 --format zip --out - | bsdtar -tf -
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunSource(os.Stdout, os.Stderr, args, client, sourceOpts.Format, sourceOpts.Out)
+	},
+}
+
+var sourceOpts = struct {
+	Format string
+	Out    string
+}{}
+
+func RunSource(stdout, stderr io.Writer, args []string, client github.ClientInterface, format, out string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("Please specify repository <user/name> and tag.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	var ext string
+
+	switch github.ArchiveFormat(format) {
+	case github.ArchiveFormatTar:
+		ext = ".tar.gz"
+	case github.ArchiveFormatZip:
+		ext = ".zip"
+	default:
+		return fmt.Errorf("Invalid format: %s (must be tar or zip)", format)
+	}
+
+	if out == "" {
+		// tag can have slash, e.g. tool/v1.0.0
+		out = repo + "-" + strings.ReplaceAll(tag, "/", "-") + ext
+	}
+
+	ctx := context.Background()
+
+	rc, err := client.OpenSourceArchive(ctx, owner, repo, tag, github.ArchiveFormat(format))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if out == "-" {
+		_, err := io.Copy(stdout, rc)
+		return err
+	}
+
+	// written into temporary file first not to leave broken archive
+	tmp := out + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(f, h), rc); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s  %s\n", hex.EncodeToString(h.Sum(nil)), filepath.ToSlash(out))
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(sourceCmd)
+
+	sourceCmd.Flags().StringVar(&sourceOpts.Format, "format", string(github.ArchiveFormatTar), "Archive format: tar or zip")
+	sourceCmd.Flags().StringVarP(&sourceOpts.Out, "out", "o", "", "File to save the archive, - for stdout (default REPO-TAG.tar.gz or REPO-TAG.zip)")
+}
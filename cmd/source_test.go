@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForSource struct {
+	fakeClient
+}
+
+func (c fakeClientForSource) OpenSourceArchive(ctx context.Context, owner, repo, tag string, format github.ArchiveFormat) (io.ReadCloser, error) {
+	if tag != "v1.0.0" {
+		return nil, &github.Error{Kind: github.ErrTagNotFound, Owner: owner, Repo: repo, Tag: tag}
+	}
+
+	return ioutil.NopCloser(strings.NewReader(string(format) + "ball")), nil
+}
+
+func TestRunSource(t *testing.T) {
+	client := fakeClientForSource{}
+
+	dir := t.TempDir()
+
+	testcases := []struct {
+		format   string
+		out      string
+		wantFile string
+		want     string
+	}{
+		{
+			format:   "tar",
+			wantFile: "repo-v1.0.0.tar.gz",
+			want:     "tarball",
+		},
+		{
+			format:   "zip",
+			out:      filepath.Join(dir, "source.zip"),
+			wantFile: filepath.Join(dir, "source.zip"),
+			want:     "zipball",
+		},
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range testcases {
+		stdout := &bytes.Buffer{}
+
+		if err := RunSource(stdout, &bytes.Buffer{}, []string{"owner/repo", "v1.0.0"}, client, tc.format, tc.out); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		b, err := ioutil.ReadFile(tc.wantFile)
+		if err != nil {
+			t.Errorf("want: %s saved, got: %s", tc.wantFile, err)
+			continue
+		}
+
+		if string(b) != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, string(b))
+		}
+
+		sum := sha256.Sum256([]byte(tc.want))
+		wantStdout := hex.EncodeToString(sum[:]) + "  " + filepath.ToSlash(tc.wantFile) + "\n"
+
+		if stdout.String() != wantStdout {
+			t.Errorf("stdout want: %q, got: %q", wantStdout, stdout.String())
+		}
+	}
+
+	stdout := &bytes.Buffer{}
+
+	if err := RunSource(stdout, &bytes.Buffer{}, []string{"owner/repo", "v1.0.0"}, client, "tar", "-"); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if stdout.String() != "tarball" {
+		t.Errorf("want: %q, got: %q", "tarball", stdout.String())
+	}
+}
+
+func TestRunSource_error(t *testing.T) {
+	client := fakeClientForSource{}
+
+	testcases := []struct {
+		args   []string
+		format string
+		want   string
+	}{
+		{
+			args:   []string{"owner/repo"},
+			format: "tar",
+			want:   "Please specify repository <user/name> and tag.",
+		},
+		{
+			args:   []string{"owner/repo", "v1.0.0"},
+			format: "7z",
+			want:   "Invalid format: 7z (must be tar or zip)",
+		},
+		{
+			args:   []string{"owner/repo", "v9.9.9"},
+			format: "tar",
+			want:   "owner/repo@v9.9.9: tag not found",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := RunSource(&bytes.Buffer{}, &bytes.Buffer{}, tc.args, client, tc.format, filepath.Join(t.TempDir(), "out"))
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/dtan4/ghrls/semver"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// number of recent months/quarters whose release counts are reported
+	statsMonths   = 12
+	statsQuarters = 8
+	// number of authors reported
+	statsTopAuthors = 5
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats REPOSITORY",
+	Short: "Report release cadence and statistics",
+	Long: `Report release cadence and statistics
+
+Draft releases are ignored. Release time is published time of the release.
+Prerelease to GA lead time is time from the first prerelease (e.g. v1.5.0-beta.1) to its GA release (v1.5.0).
+
+Example:
+
+$ ghrls stats dtan4/ghrls
+Releases:           25 (5 prereleases)
+First release:      2017-01-19 22:51:13 +0900 JST
+Latest release:     2021-03-01 10:00:00 +0900 JST
+Interval (median):  21.0 days
+Interval (p90):     95.2 days
+Prerelease to GA:   6.5 days (median of 4 releases)
+Total downloads:    12345
+
+MONTH      RELEASES
+2021-03    1
+...
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return runWithPager(os.Stdout, func(stdout io.Writer) error {
+			return RunStats(stdout, os.Stderr, args, client, tf)
+		})
+	},
+}
+
+// releaseStats represents statistics of releases
+type releaseStats struct {
+	Releases       int
+	Prereleases    int
+	First          time.Time
+	Latest         time.Time
+	IntervalMedian time.Duration
+	IntervalP90    time.Duration
+	// LeadTimeMedian is median of prerelease to GA lead time of LeadTimeCount releases
+	LeadTimeMedian time.Duration
+	LeadTimeCount  int
+	Downloads      int
+	// Months and Quarters are release counts of recent periods, oldest first
+	Months   []periodCount
+	Quarters []periodCount
+	Authors  []authorCount
+}
+
+type periodCount struct {
+	Period string
+	Count  int
+}
+
+type authorCount struct {
+	Author string
+	Count  int
+}
+
+// releaseTime returns time the release was published, or created for unpublished one
+func releaseTime(r *github.Release) time.Time {
+	if !r.PublishedAt.IsZero() {
+		return r.PublishedAt
+	}
+
+	return r.CreatedAt
+}
+
+// computeStats computes statistics of the given releases
+// Periods are split in loc and end at now
+func computeStats(tags []*github.Tag, now time.Time, loc *time.Location) *releaseStats {
+	s := &releaseStats{}
+
+	releases := []*github.Tag{}
+
+	for _, t := range tags {
+		if t.Release == nil || t.Release.Draft {
+			continue
+		}
+
+		releases = append(releases, t)
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releaseTime(releases[i].Release).Before(releaseTime(releases[j].Release))
+	})
+
+	s.Releases = len(releases)
+
+	authors := map[string]int{}
+	months := map[string]int{}
+	quarters := map[string]int{}
+
+	for _, t := range releases {
+		r := t.Release
+
+		if r.Prerelease {
+			s.Prereleases++
+		}
+
+		for _, a := range r.Assets {
+			s.Downloads += a.DownloadCount
+		}
+
+		if r.Author != "" {
+			authors[r.Author]++
+		}
+
+		rt := releaseTime(r).In(loc)
+		months[monthPeriod(rt)]++
+		quarters[quarterPeriod(rt)]++
+	}
+
+	if len(releases) > 0 {
+		s.First = releaseTime(releases[0].Release)
+		s.Latest = releaseTime(releases[len(releases)-1].Release)
+	}
+
+	intervals := []time.Duration{}
+
+	for i := 1; i < len(releases); i++ {
+		intervals = append(intervals, releaseTime(releases[i].Release).Sub(releaseTime(releases[i-1].Release)))
+	}
+
+	s.IntervalMedian = percentile(intervals, 50)
+	s.IntervalP90 = percentile(intervals, 90)
+
+	leadTimes := prereleaseLeadTimes(releases)
+	s.LeadTimeMedian = percentile(leadTimes, 50)
+	s.LeadTimeCount = len(leadTimes)
+
+	now = now.In(loc)
+	firstMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+
+	for i := statsMonths - 1; i >= 0; i-- {
+		p := monthPeriod(firstMonth.AddDate(0, -i, 0))
+		s.Months = append(s.Months, periodCount{Period: p, Count: months[p]})
+	}
+
+	firstQuarter := time.Date(now.Year(), now.Month()-(now.Month()-1)%3, 1, 0, 0, 0, 0, loc)
+
+	for i := statsQuarters - 1; i >= 0; i-- {
+		p := quarterPeriod(firstQuarter.AddDate(0, -3*i, 0))
+		s.Quarters = append(s.Quarters, periodCount{Period: p, Count: quarters[p]})
+	}
+
+	for author, count := range authors {
+		s.Authors = append(s.Authors, authorCount{Author: author, Count: count})
+	}
+
+	sort.Slice(s.Authors, func(i, j int) bool {
+		if s.Authors[i].Count != s.Authors[j].Count {
+			return s.Authors[i].Count > s.Authors[j].Count
+		}
+
+		return s.Authors[i].Author < s.Authors[j].Author
+	})
+
+	if len(s.Authors) > statsTopAuthors {
+		s.Authors = s.Authors[:statsTopAuthors]
+	}
+
+	return s
+}
+
+// prereleaseLeadTimes returns time from the first prerelease to GA release of each SemVer version
+// releases must be sorted by release time
+func prereleaseLeadTimes(releases []*github.Tag) []time.Duration {
+	firstPre := map[string]time.Time{}
+	leadTimes := []time.Duration{}
+
+	for _, t := range releases {
+		v, err := semver.Parse(t.Name)
+		if err != nil {
+			continue
+		}
+
+		core := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+		if v.IsPrerelease() {
+			if _, ok := firstPre[core]; !ok {
+				firstPre[core] = releaseTime(t.Release)
+			}
+
+			continue
+		}
+
+		if pre, ok := firstPre[core]; ok {
+			leadTimes = append(leadTimes, releaseTime(t.Release).Sub(pre))
+			delete(firstPre, core)
+		}
+	}
+
+	return leadTimes
+}
+
+// percentile returns p-th percentile of ds by nearest-rank method
+// Zero is returned for empty ds
+func percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func monthPeriod(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+func quarterPeriod(t time.Time) string {
+	return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+}
+
+// formatDays formats duration in days
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1f days", d.Hours()/24)
+}
+
+// RunStats prints statistics of releases of the given repository
+func RunStats(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	ctx := context.Background()
+
+	tags := []*github.Tag{}
+
+	if err := client.WalkReleases(ctx, owner, repo, func(t *github.Tag) error {
+		tags = append(tags, t)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	s := computeStats(tags, tf.now(), tf.location)
+
+	if s.Releases == 0 {
+		fmt.Fprintf(stdout, "%s/%s has no release.\n", owner, repo)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Releases:\t%d (%d prereleases)\n", s.Releases, s.Prereleases)
+	fmt.Fprintln(w, "First release:\t"+tf.Format(s.First))
+	fmt.Fprintln(w, "Latest release:\t"+tf.Format(s.Latest))
+
+	if s.Releases > 1 {
+		fmt.Fprintln(w, "Interval (median):\t"+formatDays(s.IntervalMedian))
+		fmt.Fprintln(w, "Interval (p90):\t"+formatDays(s.IntervalP90))
+	}
+
+	if s.LeadTimeCount > 0 {
+		fmt.Fprintf(w, "Prerelease to GA:\t%s (median of %d releases)\n", formatDays(s.LeadTimeMedian), s.LeadTimeCount)
+	}
+
+	fmt.Fprintf(w, "Total downloads:\t%d\n", s.Downloads)
+	w.Flush()
+
+	fmt.Fprintln(stdout, "")
+
+	w = tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "MONTH\tRELEASES")
+
+	for _, p := range s.Months {
+		fmt.Fprintf(w, "%s\t%d\n", p.Period, p.Count)
+	}
+
+	w.Flush()
+
+	fmt.Fprintln(stdout, "")
+
+	w = tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "QUARTER\tRELEASES")
+
+	for _, p := range s.Quarters {
+		fmt.Fprintf(w, "%s\t%d\n", p.Period, p.Count)
+	}
+
+	w.Flush()
+
+	fmt.Fprintln(stdout, "")
+
+	w = tabwriter.NewWriter(stdout, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "AUTHOR\tRELEASES")
+
+	for _, a := range s.Authors {
+		fmt.Fprintf(w, "%s\t%d\n", a.Author, a.Count)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+func statsTestClient() fakeClientForExport {
+	release := func(name, author string, published time.Time, prerelease, draft bool, downloads ...int) *github.Tag {
+		assets := []*github.Asset{}
+		for _, d := range downloads {
+			assets = append(assets, &github.Asset{DownloadCount: d})
+		}
+
+		return &github.Tag{
+			Name: name,
+			Release: &github.Release{
+				Assets:      assets,
+				Author:      author,
+				CreatedAt:   published.Add(-time.Hour),
+				Draft:       draft,
+				Prerelease:  prerelease,
+				PublishedAt: published,
+			},
+		}
+	}
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2021, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	return fakeClientForExport{
+		Releases: []*github.Tag{
+			release("v1.3.0", "alice", time.Time{}, false, true),
+			release("v1.2.0", "bob", day(time.March, 20), false, false, 5),
+			release("v1.2.0-rc.2", "bob", day(time.March, 15), true, false),
+			release("v1.2.0-rc.1", "bob", day(time.March, 10), true, false),
+			release("v1.1.0", "alice", day(time.February, 1), false, false, 10, 20),
+			release("v1.0.0", "alice", day(time.January, 21), false, false, 100),
+			release("v1.0.0-beta.1", "alice", day(time.January, 1), true, false),
+		},
+	}
+}
+
+func TestRunStats(t *testing.T) {
+	tf := NewTimeFormatter(time.UTC, "2006-01-02")
+	tf.now = func() time.Time { return time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC) }
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunStats(stdout, stderr, []string{"dtan4/ghrls"}, statsTestClient(), tf); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	// intervals: 20, 11, 37, 5, 5 days
+	// lead times: 20 days (v1.0.0), 10 days (v1.2.0)
+	want := `Releases:           6 (3 prereleases)
+First release:      2021-01-01
+Latest release:     2021-03-20
+Interval (median):  11.0 days
+Interval (p90):     37.0 days
+Prerelease to GA:   10.0 days (median of 2 releases)
+Total downloads:    135
+
+MONTH      RELEASES
+2020-05    0
+2020-06    0
+2020-07    0
+2020-08    0
+2020-09    0
+2020-10    0
+2020-11    0
+2020-12    0
+2021-01    2
+2021-02    1
+2021-03    3
+2021-04    0
+
+QUARTER    RELEASES
+2019-Q3    0
+2019-Q4    0
+2020-Q1    0
+2020-Q2    0
+2020-Q3    0
+2020-Q4    0
+2021-Q1    6
+2021-Q2    0
+
+AUTHOR    RELEASES
+alice     3
+bob       3
+`
+	if stdout.String() != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, stdout.String())
+	}
+}
+
+func TestRunStats_noRelease(t *testing.T) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	if err := RunStats(stdout, stderr, []string{"dtan4/ghrls"}, fakeClientForExport{}, NewTimeFormatter(time.UTC, "default")); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "dtan4/ghrls has no release.\n"
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	ds := []time.Duration{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+
+	testcases := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{p: 0, want: 1},
+		{p: 50, want: 5},
+		{p: 90, want: 9},
+		{p: 100, want: 10},
+	}
+
+	for _, tc := range testcases {
+		if got := percentile(ds, tc.p); got != tc.want {
+			t.Errorf("p%v: want: %d, got: %d", tc.p, tc.want, got)
+		}
+	}
+
+	if got := percentile([]time.Duration{}, 50); got != 0 {
+		t.Errorf("want: 0, got: %d", got)
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+const (
+	snapshotsDataDir = "snapshots"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync REPOSITORY",
+	Short: "Save tags and releases for offline use",
+	Long: `Save tags and releases for offline use
+
+All tags and releases, including release notes and assets, are saved to local snapshot,
+which list, get and latest read with --offline instead of calling GitHub API.
+Snapshot is stored in $GHRLS_DATA_DIR, $XDG_DATA_HOME/ghrls or ~/.local/share/ghrls, and replaced on every sync.
+
+Example:
+
+$ ghrls sync dtan4/ghrls
+Synced 12 tags and 10 releases of dtan4/ghrls
+
+$ ghrls list dtan4/ghrls --offline
+`,
+	Annotations: map[string]string{annotationRecordRepository: ""},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tf, err := newTimeFormatter()
+		if err != nil {
+			return err
+		}
+
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunSync(os.Stdout, os.Stderr, args, client, tf)
+	},
+}
+
+// RunSync saves all tags and releases of the given repository to local snapshot
+func RunSync(stdout, stderr io.Writer, args []string, client github.ClientInterface, tf *TimeFormatter) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Please specify repository <user/name>.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	// validated before calling API not to waste it
+	if _, err := snapshotFile(owner, repo); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	s, err := github.TakeSnapshot(ctx, client, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	s.SyncedAt = tf.now()
+
+	if err := saveSnapshot(s); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Synced %d tags and %d releases of %s/%s\n", len(s.Tags), len(s.Releases), owner, repo)
+
+	return nil
+}
+
+// newOfflineClient creates client serving tags and releases from snapshots saved by sync
+func newOfflineClient() *github.SnapshotClient {
+	return github.NewSnapshotClient(loadSnapshot)
+}
+
+// newClientOrOffline creates GitHub API client, or offline client if offline is true
+func newClientOrOffline(offline bool) (github.ClientInterface, error) {
+	if offline {
+		return newOfflineClient(), nil
+	}
+
+	return newClient()
+}
+
+// snapshotFile returns file which stores snapshot of the given repository
+func snapshotFile(owner, repo string) (string, error) {
+	if !isSafePathElement(owner) || !isSafePathElement(repo) {
+		return "", fmt.Errorf("Invalid repository name: %s/%s", owner, repo)
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, snapshotsDataDir, owner, repo+".json"), nil
+}
+
+// loadSnapshot returns snapshot of the given repository saved by sync
+func loadSnapshot(owner, repo string) (*github.Snapshot, error) {
+	filename, err := snapshotFile(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s/%s has not been synced. Please run `ghrls sync %s/%s` first.", owner, repo, owner, repo)
+		}
+		return nil, err
+	}
+
+	var s github.Snapshot
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot of %s/%s: %w", owner, repo, err)
+	}
+
+	return &s, nil
+}
+
+// saveSnapshot replaces snapshot of the repository
+func saveSnapshot(s *github.Snapshot) error {
+	filename, err := snapshotFile(s.Owner, s.Repo)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, b)
+}
+
+func init() {
+	RootCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSync_offline(t *testing.T) {
+	setDataDir(t)
+
+	tf := NewTimeFormatter(time.UTC, "")
+	tf.now = func() time.Time { return time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC) }
+
+	stdout := &bytes.Buffer{}
+
+	if err := RunSync(stdout, &bytes.Buffer{}, []string{"dtan4/ghrls"}, exportTestClient(), tf); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if want := "Synced 3 tags and 3 releases of dtan4/ghrls\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	s, err := loadSnapshot("dtan4", "ghrls")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if !s.SyncedAt.Equal(tf.now()) {
+		t.Errorf("want: %s, got: %s", tf.now(), s.SyncedAt)
+	}
+
+	client := newOfflineClient()
+
+	stdout.Reset()
+
+	if err := RunList(stdout, &bytes.Buffer{}, []string{"dtan4/ghrls"}, client, tf, nil); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "" +
+		"TAG       TYPE           CREATEDAT                        NAME\n" +
+		"v1.1.0    TAG+RELEASE    2021-03-02 00:00:00 +0000 UTC    v1.1.0, \"stable\"\n" +
+		"v1.0.1    TAG                                             \n" +
+		"v1.0.0    TAG+RELEASE    2021-03-01 00:00:00 +0000 UTC    v1.0.0\n"
+
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+
+	stdout.Reset()
+
+	if err := RunGet(stdout, &bytes.Buffer{}, []string{"dtan4/ghrls", "v1.1.0"}, client, tf, nil); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	for _, line := range []string{
+		"Commit:      bbb\n",
+		"URL:         https://github.com/dtan4/ghrls/releases/tag/v1.1.0\n",
+	} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("want: %q in output, got: %q", line, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+
+	if err := RunLatest(stdout, &bytes.Buffer{}, []string{"dtan4/ghrls"}, client); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	// v1.2.0 is draft and v1.0.0 is prerelease
+	if want := "v1.1.0\n"; stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunSync_offlineError(t *testing.T) {
+	setDataDir(t)
+
+	tf := NewTimeFormatter(time.UTC, "")
+
+	if err := RunSync(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/ghrls"}, exportTestClient(), tf); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	client := newOfflineClient()
+
+	testcases := []struct {
+		run  func() error
+		want string
+	}{
+		{
+			run: func() error {
+				return RunSync(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/.."}, exportTestClient(), tf)
+			},
+			want: "Invalid repository name: dtan4/..",
+		},
+		{
+			run: func() error {
+				return RunList(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/other"}, client, tf, nil)
+			},
+			want: "dtan4/other has not been synced. Please run `ghrls sync dtan4/other` first.",
+		},
+		{
+			// draft release is not visible as online
+			run: func() error {
+				return RunGet(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/ghrls", "v1.2.0"}, client, tf, nil)
+			},
+			want: "dtan4/ghrls@v1.2.0: release not found",
+		},
+		{
+			run: func() error {
+				return RunGet(&bytes.Buffer{}, &bytes.Buffer{}, []string{"dtan4/ghrls", "v1.0.1"}, client, tf, nil)
+			},
+			want: "dtan4/ghrls@v1.0.1: release not found",
+		},
+	}
+
+	for _, tc := range testcases {
+		err := tc.run()
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/dtan4/ghrls/markdown"
+)
+
+// isTerminal reports whether w is connected to terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// supportsHyperlinks reports whether the terminal is known to support OSC 8 hyperlinks
+// GHRLS_HYPERLINKS=1 or 0 overrides the detection
+func supportsHyperlinks() bool {
+	if v := os.Getenv("GHRLS_HYPERLINKS"); v != "" {
+		b, err := strconv.ParseBool(v)
+		return err == nil && b
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper":
+		return true
+	}
+
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+
+	// VTE-based terminals (GNOME Terminal etc.) support hyperlinks since 0.50
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+
+	return false
+}
+
+// newMarkdownRenderer returns renderer for release notes printed to w
+// nil is returned if w is not terminal, so that release notes are printed as raw Markdown
+func newMarkdownRenderer(w io.Writer) *markdown.Renderer {
+	if !isTerminal(w) {
+		return nil
+	}
+
+	_, noColor := os.LookupEnv("NO_COLOR")
+
+	return &markdown.Renderer{
+		Color:      !noColor,
+		Hyperlinks: supportsHyperlinks(),
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	timeFormatDefault  = "default"
+	timeFormatRFC3339  = "rfc3339"
+	timeFormatRelative = "relative"
+	timeFormatUnix     = "unix"
+)
+
+// TimeFormatter formats timestamps for output in the configured timezone and format
+type TimeFormatter struct {
+	location *time.Location
+	format   string
+	now      func() time.Time
+}
+
+// NewTimeFormatter creates new TimeFormatter object
+// format is one of "default", "rfc3339", "relative", "unix" or Go time layout (e.g. "2006-01-02")
+// Empty format is treated as "default", which prints time.Time.String()
+func NewTimeFormatter(location *time.Location, format string) *TimeFormatter {
+	if location == nil {
+		location = time.Local
+	}
+
+	if format == "" {
+		format = timeFormatDefault
+	}
+
+	return &TimeFormatter{
+		location: location,
+		format:   format,
+		now:      time.Now,
+	}
+}
+
+// LoadLocation returns the timezone of the given name
+// Empty name is treated as local timezone
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid timezone: %s", name)
+	}
+
+	return loc, nil
+}
+
+// Format returns formatted timestamp
+// Zero time is formatted as empty string
+func (f *TimeFormatter) Format(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	t = t.In(f.location)
+
+	switch f.format {
+	case timeFormatDefault:
+		return t.String()
+	case timeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	case timeFormatRelative:
+		return relativeTime(t, f.now())
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(f.format)
+	}
+}
+
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+
+	suffix := "ago"
+	if d < 0 {
+		d = -d
+		suffix = "later"
+	}
+
+	var n int64
+	var unit string
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int64(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int64(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int64(d/(365*24*time.Hour)), "year"
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+
+	return fmt.Sprintf("%d %s %s", n, unit, suffix)
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeFormatterFormat(t *testing.T) {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2017, 1, 15, 4, 51, 15, 0, time.UTC)
+	ts := time.Date(2017, 1, 12, 4, 51, 15, 0, time.UTC)
+
+	testcases := []struct {
+		location *time.Location
+		format   string
+		t        time.Time
+		want     string
+	}{
+		{
+			location: jst,
+			format:   "",
+			t:        ts,
+			want:     "2017-01-12 13:51:15 +0900 JST",
+		},
+		{
+			location: time.UTC,
+			format:   "default",
+			t:        ts,
+			want:     "2017-01-12 04:51:15 +0000 UTC",
+		},
+		{
+			location: jst,
+			format:   "rfc3339",
+			t:        ts,
+			want:     "2017-01-12T13:51:15+09:00",
+		},
+		{
+			location: jst,
+			format:   "unix",
+			t:        ts,
+			want:     "1484196675",
+		},
+		{
+			location: jst,
+			format:   "relative",
+			t:        ts,
+			want:     "3 days ago",
+		},
+		{
+			location: jst,
+			format:   "2006/01/02 15:04",
+			t:        ts,
+			want:     "2017/01/12 13:51",
+		},
+		{
+			location: jst,
+			format:   "rfc3339",
+			t:        time.Time{},
+			want:     "",
+		},
+	}
+
+	for _, tc := range testcases {
+		f := NewTimeFormatter(tc.location, tc.format)
+		f.now = func() time.Time { return now }
+
+		if got := f.Format(tc.t); got != tc.want {
+			t.Errorf("format %q want: %q, got: %q", tc.format, tc.want, got)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2017, 1, 12, 4, 51, 15, 0, time.UTC)
+
+	testcases := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 10 * time.Second, want: "just now"},
+		{d: time.Minute, want: "1 minute ago"},
+		{d: 5 * time.Hour, want: "5 hours ago"},
+		{d: 24 * time.Hour, want: "1 day ago"},
+		{d: 65 * 24 * time.Hour, want: "2 months ago"},
+		{d: 800 * 24 * time.Hour, want: "2 years ago"},
+		{d: -3 * time.Hour, want: "3 hours later"},
+	}
+
+	for _, tc := range testcases {
+		if got := relativeTime(now.Add(-tc.d), now); got != tc.want {
+			t.Errorf("%s want: %q, got: %q", tc.d, tc.want, got)
+		}
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	testcases := []struct {
+		name string
+		want *time.Location
+	}{
+		{name: "", want: time.Local},
+		{name: "UTC", want: time.UTC},
+	}
+
+	for _, tc := range testcases {
+		got, err := LoadLocation(tc.name)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+		}
+
+		if got != tc.want {
+			t.Errorf("want: %s, got: %s", tc.want, got)
+		}
+	}
+
+	if _, err := LoadLocation("Foo/Bar"); err == nil {
+		t.Error("want: error, got: nil")
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dtan4/ghrls/github"
+	"github.com/spf13/cobra"
+)
+
+// uploadCmd represents the upload command
+var uploadCmd = &cobra.Command{
+	Use:   "upload REPOSITORY TAG FILE...",
+	Short: "Upload assets to release",
+	Long: `Upload assets to release
+
+FILE can be glob pattern, e.g. "dist/*.tar.gz". Quote it to let ghrls expand it.
+Content type is detected from extension or content of the file.
+
+Example:
+
+$ ghrls upload dtan4/ghrls v1.0.0 'dist/*.tar.gz' dist/checksums.txt
+https://github.com/dtan4/ghrls/releases/download/v1.0.0/ghrls_linux_amd64.tar.gz
+https://github.com/dtan4/ghrls/releases/download/v1.0.0/ghrls_darwin_amd64.tar.gz
+https://github.com/dtan4/ghrls/releases/download/v1.0.0/checksums.txt
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newClient()
+		if err != nil {
+			return err
+		}
+
+		return RunUpload(os.Stdout, os.Stderr, args, client, uploadOpts.Clobber)
+	},
+}
+
+var uploadOpts = struct {
+	Clobber bool
+}{}
+
+func RunUpload(stdout, stderr io.Writer, args []string, client github.ClientInterface, clobber bool) error {
+	if len(args) < 3 {
+		return fmt.Errorf("Please specify repository <user/name>, tag and files.")
+	}
+
+	ss := strings.Split(args[0], "/")
+	if len(ss) != 2 {
+		return fmt.Errorf("Invalid repository name: %s", args[0])
+	}
+	owner, repo := ss[0], ss[1]
+
+	tag := args[1]
+
+	files, err := expandFiles(args[2:])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	for _, file := range files {
+		asset, err := client.UploadReleaseAsset(ctx, owner, repo, tag, file, clobber)
+		if err != nil {
+			if errors.Is(err, github.ErrAssetExists) {
+				return fmt.Errorf("Asset already exists: %s (use --clobber to replace)", filepath.Base(file))
+			}
+			return err
+		}
+
+		fmt.Fprintln(stdout, asset.URL)
+	}
+
+	return nil
+}
+
+// expandFiles expands glob patterns into regular files
+// Duplicated files are removed, and it is an error if pattern matches nothing
+func expandFiles(patterns []string) ([]string, error) {
+	files := []string{}
+	seen := map[string]bool{}
+	names := map[string]string{}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid file pattern: %s", pattern)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("No such file: %s", pattern)
+		}
+
+		for _, m := range matches {
+			if seen[m] {
+				continue
+			}
+
+			fi, err := os.Stat(m)
+			if err != nil {
+				return nil, err
+			}
+
+			if fi.IsDir() {
+				return nil, fmt.Errorf("Cannot upload directory: %s", m)
+			}
+
+			// asset name is base name of the file
+			name := filepath.Base(m)
+			if other, ok := names[name]; ok {
+				return nil, fmt.Errorf("Duplicated asset name %s: %s and %s", name, other, m)
+			}
+
+			seen[m] = true
+			names[name] = m
+			files = append(files, m)
+		}
+	}
+
+	return files, nil
+}
+
+func init() {
+	RootCmd.AddCommand(uploadCmd)
+
+	uploadCmd.Flags().BoolVar(&uploadOpts.Clobber, "clobber", false, "Replace existing assets of the same name")
+}
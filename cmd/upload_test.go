@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dtan4/ghrls/github"
+)
+
+type fakeClientForAsset struct {
+	fakeClient
+
+	Err     error
+	uploads *[]string
+}
+
+func (c fakeClientForAsset) DeleteReleaseAsset(ctx context.Context, owner, repo, tag, name string) error {
+	return c.Err
+}
+
+func (c fakeClientForAsset) UploadReleaseAsset(ctx context.Context, owner, repo, tag, filename string, clobber bool) (*github.Asset, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+
+	*c.uploads = append(*c.uploads, fmt.Sprintf("%s clobber=%t", filepath.Base(filename), clobber))
+
+	name := filepath.Base(filename)
+
+	return &github.Asset{
+		Name: name,
+		URL:  fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", owner, repo, tag, name),
+	}, nil
+}
+
+// createFiles creates empty files in temporary directory and returns the directory
+func createFiles(t *testing.T, names ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestRunUpload_success(t *testing.T) {
+	dir := createFiles(t, "ghrls_darwin_amd64.tar.gz", "ghrls_linux_amd64.tar.gz", "checksums.txt")
+
+	uploads := []string{}
+	client := fakeClientForAsset{uploads: &uploads}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	args := []string{"dtan4/ghrls", "v1.0.0", filepath.Join(dir, "*.tar.gz"), filepath.Join(dir, "checksums.txt")}
+
+	if err := RunUpload(stdout, stderr, args, client, true); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	wantUploads := []string{
+		"ghrls_darwin_amd64.tar.gz clobber=true",
+		"ghrls_linux_amd64.tar.gz clobber=true",
+		"checksums.txt clobber=true",
+	}
+	if !reflect.DeepEqual(uploads, wantUploads) {
+		t.Errorf("want: %q, got: %q", wantUploads, uploads)
+	}
+
+	want := `https://github.com/dtan4/ghrls/releases/download/v1.0.0/ghrls_darwin_amd64.tar.gz
+https://github.com/dtan4/ghrls/releases/download/v1.0.0/ghrls_linux_amd64.tar.gz
+https://github.com/dtan4/ghrls/releases/download/v1.0.0/checksums.txt
+`
+	if stdout.String() != want {
+		t.Errorf("want: %q, got: %q", want, stdout.String())
+	}
+}
+
+func TestRunUpload_error(t *testing.T) {
+	dir := createFiles(t, "ghrls.tar.gz", "sub/ghrls.tar.gz")
+
+	uploads := []string{}
+
+	testcases := []struct {
+		args   []string
+		client github.ClientInterface
+		want   string
+	}{
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0"},
+			client: fakeClientForAsset{uploads: &uploads},
+			want:   "Please specify repository <user/name>, tag and files.",
+		},
+		{
+			args:   []string{"ghrls", "v1.0.0", "ghrls.tar.gz"},
+			client: fakeClientForAsset{uploads: &uploads},
+			want:   "Invalid repository name: ghrls",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", filepath.Join(dir, "*.zip")},
+			client: fakeClientForAsset{uploads: &uploads},
+			want:   "No such file: " + filepath.Join(dir, "*.zip"),
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", filepath.Join(dir, "sub")},
+			client: fakeClientForAsset{uploads: &uploads},
+			want:   "Cannot upload directory: " + filepath.Join(dir, "sub"),
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", filepath.Join(dir, "ghrls.tar.gz"), filepath.Join(dir, "sub", "*")},
+			client: fakeClientForAsset{uploads: &uploads},
+			want:   fmt.Sprintf("Duplicated asset name ghrls.tar.gz: %s and %s", filepath.Join(dir, "ghrls.tar.gz"), filepath.Join(dir, "sub", "ghrls.tar.gz")),
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v1.0.0", filepath.Join(dir, "ghrls.tar.gz")},
+			client: fakeClientForAsset{Err: fmt.Errorf("ghrls.tar.gz: %w", github.ErrAssetExists), uploads: &uploads},
+			want:   "Asset already exists: ghrls.tar.gz (use --clobber to replace)",
+		},
+		{
+			args:   []string{"dtan4/ghrls", "v9.9.9", filepath.Join(dir, "ghrls.tar.gz")},
+			client: fakeClientForAsset{Err: &github.Error{Kind: github.ErrReleaseNotFound, Owner: "dtan4", Repo: "ghrls", Tag: "v9.9.9"}, uploads: &uploads},
+			want:   "dtan4/ghrls@v9.9.9: release not found",
+		},
+	}
+
+	for _, tc := range testcases {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+		err := RunUpload(stdout, stderr, tc.args, tc.client, false)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+
+	if len(uploads) > 0 {
+		t.Errorf("want: no uploads, got: %q", uploads)
+	}
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultAPIBaseURL = "https://api.github.com/"
+
+	// GitHub rejects JWTs valid for more than 10 minutes
+	// https://docs.github.com/en/developers/apps/authenticating-with-github-apps#authenticating-as-a-github-app
+	appJWTLifetime = 9 * time.Minute
+	// tolerate clock drift between the local machine and GitHub
+	appJWTClockSkew = 60 * time.Second
+)
+
+// appTokenSource mints GitHub App installation access tokens
+type appTokenSource struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	baseURL        string
+	httpClient     *http.Client
+	now            func() time.Time
+}
+
+// NewAppTokenSource creates a token source which authenticates as the given GitHub App installation.
+// Installation tokens are refreshed automatically shortly before they expire.
+// baseURL can be empty to use api.github.com, and transport can be nil to use http.DefaultTransport.
+func NewAppTokenSource(appID, installationID int64, privateKeyPEM []byte, baseURL string, transport http.RoundTripper) (oauth2.TokenSource, error) {
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if baseURL == "" {
+		baseURL = defaultAPIBaseURL
+	}
+
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	ts := &appTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     key,
+		baseURL:        baseURL,
+		httpClient:     &http.Client{Transport: transport},
+		now:            time.Now,
+	}
+
+	return oauth2.ReuseTokenSource(nil, ts), nil
+}
+
+// Token exchanges a freshly signed JWT for an installation access token
+func (s *appTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.signJWT()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", s.baseURL, s.installationID)
+
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create installation access token: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var r struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: r.Token,
+		TokenType:   "token",
+		Expiry:      r.ExpiresAt,
+	}, nil
+}
+
+func (s *appTokenSource) signJWT() (string, error) {
+	now := s.now()
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]int64{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": s.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+
+	return key, nil
+}
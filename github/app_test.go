@@ -0,0 +1,241 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generatePrivateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+func TestNewAppTokenSource(t *testing.T) {
+	key, keyPEM := generatePrivateKey(t)
+
+	var requests int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/5678/access_tokens" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		ss := strings.Split(jwt, ".")
+		if len(ss) != 3 {
+			t.Errorf("want: JWT, got: %q", jwt)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		sig, err := base64.RawURLEncoding.DecodeString(ss[2])
+		if err != nil {
+			t.Errorf("invalid JWT signature encoding: %s", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		digest := sha256.Sum256([]byte(ss[0] + "." + ss[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig); err != nil {
+			t.Errorf("invalid JWT signature: %s", err)
+		}
+
+		payload, err := base64.RawURLEncoding.DecodeString(ss[1])
+		if err != nil {
+			t.Errorf("invalid JWT payload encoding: %s", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var claims struct {
+			Iss int64 `json:"iss"`
+			Iat int64 `json:"iat"`
+			Exp int64 `json:"exp"`
+		}
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			t.Errorf("invalid JWT payload: %s", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if claims.Iss != 1234 {
+			t.Errorf("iss want: 1234, got: %d", claims.Iss)
+		}
+
+		if d := claims.Exp - claims.Iat; d <= 0 || d > 600 {
+			t.Errorf("JWT lifetime must be within 10 minutes, got: %ds", d)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token":"ghs_token%d","expires_at":%q}`, requests, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer ts.Close()
+
+	source, err := NewAppTokenSource(1234, 5678, keyPEM, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+
+		if token.AccessToken != "ghs_token1" {
+			t.Errorf("token want: %q, got: %q", "ghs_token1", token.AccessToken)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("unexpired token must be reused, got: %d requests", requests)
+	}
+}
+
+func TestNewAppTokenSource_refresh(t *testing.T) {
+	_, keyPEM := generatePrivateKey(t)
+
+	var requests int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.WriteHeader(http.StatusCreated)
+		// already expired, so that every call mints a new token
+		fmt.Fprintf(w, `{"token":"ghs_token%d","expires_at":%q}`, requests, time.Now().Add(-time.Minute).Format(time.RFC3339))
+	}))
+	defer ts.Close()
+
+	source, err := NewAppTokenSource(1234, 5678, keyPEM, ts.URL+"/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+
+		if want := fmt.Sprintf("ghs_token%d", i); token.AccessToken != want {
+			t.Errorf("token want: %q, got: %q", want, token.AccessToken)
+		}
+	}
+}
+
+func TestNewAppTokenSource_transport(t *testing.T) {
+	_, keyPEM := generatePrivateKey(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token":"ghs_recorded","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer ts.Close()
+
+	filename := filepath.Join(t.TempDir(), "recording.json")
+
+	source, err := NewAppTokenSource(1234, 5678, keyPEM, ts.URL, NewRecorder(filename, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := source.Token(); err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	// token exchange is replayed without server
+	ts.Close()
+
+	rep, err := NewReplayer(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err = NewAppTokenSource(1234, 5678, keyPEM, ts.URL, rep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if token.AccessToken != "ghs_recorded" {
+		t.Errorf("token want: %q, got: %q", "ghs_recorded", token.AccessToken)
+	}
+}
+
+func TestNewAppTokenSource_error(t *testing.T) {
+	_, keyPEM := generatePrivateKey(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"A JSON web token could not be decoded"}`)
+	}))
+	defer ts.Close()
+
+	source, err := NewAppTokenSource(1234, 5678, keyPEM, ts.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := source.Token(); err == nil {
+		t.Error("want: error, got: nil")
+	}
+}
+
+func TestNewAppTokenSource_invalidKey(t *testing.T) {
+	testcases := []struct {
+		key  []byte
+		want string
+	}{
+		{
+			key:  []byte("foobar"),
+			want: "private key is not PEM encoded",
+		},
+		{
+			key: pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: []byte("foobar"),
+			}),
+			want: "failed to parse private key",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := NewAppTokenSource(1234, 5678, tc.key, "", nil)
+		if err == nil {
+			t.Error("want: error, got: nil")
+			continue
+		}
+
+		if !strings.HasPrefix(err.Error(), tc.want) {
+			t.Errorf("error want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
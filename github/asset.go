@@ -0,0 +1,235 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-github/v33/github"
+)
+
+const (
+	// suffix of asset name to upload replacement of the existing asset
+	uploadingAssetSuffix = ".uploading"
+)
+
+var (
+	// ErrAssetExists is returned when asset with the same name already exists in the release
+	ErrAssetExists = errors.New("asset already exists")
+	// ErrAssetNotFound is returned when the release has no asset of the given name
+	ErrAssetNotFound = errors.New("asset not found")
+)
+
+// Asset represents a file attached to release
+type Asset struct {
+	ContentType   string
+	DownloadCount int
+	// ID is used to download the asset through GitHub API
+	ID   int64
+	Name string
+	Size int
+	URL  string
+}
+
+// UploadReleaseAsset uploads the given file to release of the given tag
+// Existing asset with the same name is replaced if clobber is true, otherwise ErrAssetExists is returned
+// The replacement is uploaded under temporary name and renamed after the existing asset is deleted,
+// so that failed upload does not leave the release without the asset
+func (c *Client) UploadReleaseAsset(ctx context.Context, owner, repo, tag, filename string, clobber bool) (*Asset, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	contentType, err := detectContentType(f)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := c.findRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(filename)
+
+	existing := findAsset(release, name)
+	if existing == nil {
+		asset, err := c.uploadReleaseAsset(ctx, owner, repo, tag, release.GetID(), name, contentType, f)
+		if err != nil {
+			return nil, err
+		}
+
+		return toAsset(asset), nil
+	}
+
+	if !clobber {
+		return nil, fmt.Errorf("%s: %w", name, ErrAssetExists)
+	}
+
+	tmpName := name + uploadingAssetSuffix
+
+	// left by the previous upload which failed halfway
+	if a := findAsset(release, tmpName); a != nil {
+		if _, err := c.repositories.DeleteReleaseAsset(ctx, owner, repo, a.GetID()); err != nil {
+			return nil, c.wrapError(ctx, err, owner, repo, tag, nil)
+		}
+	}
+
+	uploaded, err := c.uploadReleaseAsset(ctx, owner, repo, tag, release.GetID(), tmpName, contentType, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.repositories.DeleteReleaseAsset(ctx, owner, repo, existing.GetID()); err != nil {
+		return nil, fmt.Errorf("%s is uploaded as %s, but failed to delete the existing one: %w", name, tmpName, c.wrapError(ctx, err, owner, repo, tag, nil))
+	}
+
+	asset, _, err := c.repositories.EditReleaseAsset(ctx, owner, repo, uploaded.GetID(), &github.ReleaseAsset{
+		Name: github.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s is uploaded as %s, but failed to rename it: %w", name, tmpName, c.wrapError(ctx, err, owner, repo, tag, nil))
+	}
+
+	return toAsset(asset), nil
+}
+
+func (c *Client) uploadReleaseAsset(ctx context.Context, owner, repo, tag string, releaseID int64, name, contentType string, f *os.File) (*github.ReleaseAsset, error) {
+	asset, _, err := c.repositories.UploadReleaseAsset(ctx, owner, repo, releaseID, &github.UploadOptions{
+		Name:      name,
+		MediaType: contentType,
+	}, f)
+	if err != nil {
+		return nil, c.wrapError(ctx, err, owner, repo, tag, nil)
+	}
+
+	return asset, nil
+}
+
+// DeleteReleaseAsset deletes asset of the given name from release of the given tag
+func (c *Client) DeleteReleaseAsset(ctx context.Context, owner, repo, tag, name string) error {
+	release, err := c.findRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	a := findAsset(release, name)
+	if a == nil {
+		return fmt.Errorf("%s: %w", name, ErrAssetNotFound)
+	}
+
+	_, err = c.repositories.DeleteReleaseAsset(ctx, owner, repo, a.GetID())
+
+	return c.wrapError(ctx, err, owner, repo, tag, nil)
+}
+
+// OpenReleaseAsset reads content of the asset through GitHub API with credentials, so that assets of private repositories can be read too
+// Content is read from offset, up to length bytes unless length is 0
+func (c *Client) OpenReleaseAsset(ctx context.Context, owner, repo string, asset *Asset, offset, length int64) (io.ReadCloser, error) {
+	// GitHub API redirects to the storage, which is requested by c.download without credentials
+	rc, redirectURL, err := c.repositories.DownloadReleaseAsset(ctx, owner, repo, asset.ID, nil)
+	if err != nil {
+		return nil, c.wrapError(ctx, err, owner, repo, "", nil)
+	}
+
+	if rc == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, redirectURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset > 0 || length > 0 {
+			rng := fmt.Sprintf("bytes=%d-", offset)
+			if length > 0 {
+				rng += fmt.Sprint(offset + length - 1)
+			}
+
+			req.Header.Set("Range", rng)
+		}
+
+		resp, err := c.download.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+			return resp.Body, nil
+		case http.StatusOK:
+			rc = resp.Body
+		default:
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to download %s: %s", asset.Name, resp.Status)
+		}
+	}
+
+	// the whole content is returned if the server does not support range request
+	if offset > 0 {
+		if _, err := io.CopyN(ioutil.Discard, rc, offset); err != nil {
+			rc.Close()
+			return nil, err
+		}
+	}
+
+	if length > 0 {
+		return &limitedReadCloser{Reader: io.LimitReader(rc, length), Closer: rc}, nil
+	}
+
+	return rc, nil
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// detectContentType guesses content type of the given file from its extension, or from its content
+// File offset is rewound to the beginning
+func detectContentType(f *os.File) (string, error) {
+	if t := mime.TypeByExtension(filepath.Ext(f.Name())); t != "" {
+		return t, nil
+	}
+
+	// http.DetectContentType considers at most the first 512 bytes
+	buf := make([]byte, 512)
+
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+func findAsset(r *github.RepositoryRelease, name string) *github.ReleaseAsset {
+	for _, a := range r.Assets {
+		if a.GetName() == name {
+			return a
+		}
+	}
+
+	return nil
+}
+
+func toAsset(a *github.ReleaseAsset) *Asset {
+	return &Asset{
+		ContentType:   a.GetContentType(),
+		DownloadCount: a.GetDownloadCount(),
+		ID:            a.GetID(),
+		Name:          a.GetName(),
+		Size:          a.GetSize(),
+		URL:           a.GetBrowserDownloadURL(),
+	}
+}
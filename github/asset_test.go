@@ -0,0 +1,296 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	"golang.org/x/oauth2"
+)
+
+func (s fakeRepositoriesService) DeleteReleaseAsset(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return &github.Response{}, nil
+}
+
+func (s fakeRepositoriesService) DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error) {
+	return ioutil.NopCloser(strings.NewReader("")), "", nil
+}
+
+func (s fakeRepositoriesService) EditReleaseAsset(ctx context.Context, owner, repo string, id int64, release *github.ReleaseAsset) (*github.ReleaseAsset, *github.Response, error) {
+	return release, &github.Response{}, nil
+}
+
+func (s fakeRepositoriesService) UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error) {
+	return &github.ReleaseAsset{}, &github.Response{}, nil
+}
+
+// newAssetTestMux returns handler serving release v1.0.0 which has asset "ghrls"
+// Requests other than the release lookup are appended to calls
+func newAssetTestMux(t *testing.T, calls *[]string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 10, "tag_name": "v1.0.0", "assets": [{"id": 100, "name": "ghrls"}]}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases/assets/100", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases/10/assets", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+" "+r.Header.Get("Content-Type"))
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %s", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": 101, "name": %q, "content_type": %q, "size": %d, "browser_download_url": "https://github.com/owner/repo/releases/download/v1.0.0/%s"}`,
+			r.URL.Query().Get("name"), r.Header.Get("Content-Type"), len(b), r.URL.Query().Get("name"))
+	})
+	mux.HandleFunc("/repos/owner/repo/releases/assets/101", func(w http.ResponseWriter, r *http.Request) {
+		var a github.ReleaseAsset
+		if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+			t.Errorf("invalid request body: %s", err)
+		}
+
+		*calls = append(*calls, r.Method+" "+r.URL.Path+" "+a.GetName())
+
+		fmt.Fprintf(w, `{"id": 101, "name": %q, "content_type": "application/x-gzip", "size": 8, "browser_download_url": "https://github.com/owner/repo/releases/download/v1.0.0/%s"}`,
+			a.GetName(), a.GetName())
+	})
+
+	return mux
+}
+
+func TestUploadReleaseAsset(t *testing.T) {
+	dir := t.TempDir()
+
+	// files have no extension so that content type is detected from content regardless of mime.types of the system
+	gz := filepath.Join(dir, "ghrls")
+	if err := ioutil.WriteFile(gz, []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	txt := filepath.Join(dir, "checksums")
+	if err := ioutil.WriteFile(txt, []byte("0123abcd  ghrls\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		filename  string
+		clobber   bool
+		wantCalls []string
+		want      *Asset
+	}{
+		{
+			filename: txt,
+			wantCalls: []string{
+				"POST /repos/owner/repo/releases/10/assets?name=checksums text/plain; charset=utf-8",
+			},
+			want: &Asset{
+				ContentType: "text/plain; charset=utf-8",
+				ID:          101,
+				Name:        "checksums",
+				Size:        16,
+				URL:         "https://github.com/owner/repo/releases/download/v1.0.0/checksums",
+			},
+		},
+		{
+			filename: gz,
+			clobber:  true,
+			wantCalls: []string{
+				"POST /repos/owner/repo/releases/10/assets?name=ghrls.uploading application/x-gzip",
+				"DELETE /repos/owner/repo/releases/assets/100",
+				"PATCH /repos/owner/repo/releases/assets/101 ghrls",
+			},
+			want: &Asset{
+				ContentType: "application/x-gzip",
+				ID:          101,
+				Name:        "ghrls",
+				Size:        8,
+				URL:         "https://github.com/owner/repo/releases/download/v1.0.0/ghrls",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		calls := []string{}
+		c := newTestClient(t, newAssetTestMux(t, &calls))
+
+		got, err := c.UploadReleaseAsset(context.Background(), "owner", "repo", "v1.0.0", tc.filename, tc.clobber)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(calls, tc.wantCalls) {
+			t.Errorf("calls want: %q, got: %q", tc.wantCalls, calls)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("want: %#v, got: %#v", tc.want, got)
+		}
+	}
+}
+
+func TestUploadReleaseAsset_exists(t *testing.T) {
+	gz := filepath.Join(t.TempDir(), "ghrls")
+	if err := ioutil.WriteFile(gz, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []string{}
+	c := newTestClient(t, newAssetTestMux(t, &calls))
+
+	_, err := c.UploadReleaseAsset(context.Background(), "owner", "repo", "v1.0.0", gz, false)
+	if !errors.Is(err, ErrAssetExists) {
+		t.Errorf("want: %s, got: %v", ErrAssetExists, err)
+	}
+
+	if len(calls) > 0 {
+		t.Errorf("want: no calls, got: %q", calls)
+	}
+}
+
+func TestUploadReleaseAsset_uploadFailed(t *testing.T) {
+	gz := filepath.Join(t.TempDir(), "ghrls")
+	if err := ioutil.WriteFile(gz, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []string{}
+	mux := newAssetTestMux(t, &calls)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+
+	if _, err := c.UploadReleaseAsset(context.Background(), "owner", "repo", "v1.0.0", gz, true); err == nil {
+		t.Error("want: error, got: nil")
+	}
+
+	if len(calls) > 0 {
+		t.Errorf("existing asset must be kept, got calls: %q", calls)
+	}
+}
+
+func TestDeleteReleaseAsset(t *testing.T) {
+	testcases := []struct {
+		name      string
+		wantErr   error
+		wantCalls []string
+	}{
+		{
+			name:      "ghrls",
+			wantCalls: []string{"DELETE /repos/owner/repo/releases/assets/100"},
+		},
+		{
+			name:      "ghrls.zip",
+			wantErr:   ErrAssetNotFound,
+			wantCalls: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		calls := []string{}
+		c := newTestClient(t, newAssetTestMux(t, &calls))
+
+		err := c.DeleteReleaseAsset(context.Background(), "owner", "repo", "v1.0.0", tc.name)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("want: %v, got: %v", tc.wantErr, err)
+		}
+
+		if !reflect.DeepEqual(calls, tc.wantCalls) {
+			t.Errorf("calls want: %q, got: %q", tc.wantCalls, calls)
+		}
+	}
+}
+
+func TestOpenReleaseAsset(t *testing.T) {
+	content := "0123456789"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/assets/100", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" && r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("credentials must be sent to GitHub API, got: %q", r.Header.Get("Authorization"))
+		}
+
+		if got := r.Header.Get("Accept"); got != "application/octet-stream" {
+			t.Errorf("Accept want: application/octet-stream, got: %q", got)
+		}
+
+		http.Redirect(w, r, "/storage/ghrls?signature=xxx", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/ghrls", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("credentials must not be sent to the storage")
+		}
+
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+	})
+	// the whole content is returned regardless of Range header
+	mux.HandleFunc("/repos/owner/repo/releases/assets/200", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, content)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := NewClientWithTransport(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret"}), nil)
+
+	u, err := url.Parse(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.api.(*github.Client).BaseURL = u
+
+	testcases := []struct {
+		id     int64
+		offset int64
+		length int64
+		want   string
+	}{
+		{id: 100, want: content},
+		{id: 100, offset: 3, length: 4, want: "3456"},
+		{id: 100, offset: 8, want: "89"},
+		{id: 200, offset: 3, length: 4, want: "3456"},
+	}
+
+	for _, tc := range testcases {
+		rc, err := c.OpenReleaseAsset(context.Background(), "owner", "repo", &Asset{ID: tc.id, Name: "ghrls"}, tc.offset, tc.length)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+		}
+
+		if string(b) != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, string(b))
+		}
+	}
+}
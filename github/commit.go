@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// Commit represents git commit
+type Commit struct {
+	Author    string
+	CreatedAt time.Time
+	Message   string
+	SHA       string
+}
+
+// ListCommits retrieves commits between from (exclusive) and to, oldest first
+func (c *Client) ListCommits(ctx context.Context, owner, repo, from, to string) ([]*Commit, error) {
+	rcs, err := c.compareCommits(ctx, owner, repo, from, to)
+	if err != nil {
+		return []*Commit{}, err
+	}
+
+	commits := []*Commit{}
+
+	for _, rc := range rcs {
+		commits = append(commits, &Commit{
+			Author:    rc.GetAuthor().GetLogin(),
+			CreatedAt: rc.GetCommit().GetAuthor().GetDate(),
+			Message:   rc.GetCommit().GetMessage(),
+			SHA:       rc.GetSHA(),
+		})
+	}
+
+	return commits, nil
+}
+
+// compareCommits retrieves all commits between from (exclusive) and to, oldest first
+// Compare API returns at most 250 commits without pagination, which is not supported by go-github v33,
+// so request is built by hand. Error is returned rather than partial commits if the API still truncates them
+func (c *Client) compareCommits(ctx context.Context, owner, repo, from, to string) ([]*github.RepositoryCommit, error) {
+	allCommits := []*github.RepositoryCommit{}
+	total := 0
+
+	for page := 1; page != 0; {
+		req, err := c.api.NewRequest("GET", fmt.Sprintf("repos/%s/%s/compare/%s...%s?per_page=%d&page=%d", owner, repo, from, to, perPage, page), nil)
+		if err != nil {
+			return []*github.RepositoryCommit{}, err
+		}
+
+		var cmp github.CommitsComparison
+
+		resp, err := c.api.Do(ctx, req, &cmp)
+		if err != nil {
+			return []*github.RepositoryCommit{}, c.wrapError(ctx, err, owner, repo, from+"..."+to, ErrTagNotFound)
+		}
+
+		allCommits = append(allCommits, cmp.Commits...)
+		total = cmp.GetTotalCommits()
+		page = resp.NextPage
+	}
+
+	if len(allCommits) < total {
+		return []*github.RepositoryCommit{}, fmt.Errorf("%s/%s: GitHub returned only %d of %d commits in %s...%s, please specify narrower range", owner, repo, len(allCommits), total, from, to)
+	}
+
+	return allCommits, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListCommits(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/compare/v1.0.0...HEAD", func(w http.ResponseWriter, r *http.Request) {
+		// commits are paginated over 250 commits
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"total_commits": 2, "commits": [
+  {"sha": "bbb", "commit": {"message": "fix: typo\n\nBREAKING CHANGE: removed flag", "author": {"date": "2021-03-02T00:00:00Z"}}}
+]}`)
+			return
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		fmt.Fprint(w, `{"total_commits": 2, "commits": [
+  {"sha": "aaa", "author": {"login": "alice"}, "commit": {"message": "feat: add prune", "author": {"date": "2021-03-01T00:00:00Z"}}}
+]}`)
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ListCommits(context.Background(), "owner", "repo", "v1.0.0", "HEAD")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := []*Commit{
+		{
+			Author:    "alice",
+			CreatedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Message:   "feat: add prune",
+			SHA:       "aaa",
+		},
+		{
+			// author is not linked to GitHub user
+			CreatedAt: time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC),
+			Message:   "fix: typo\n\nBREAKING CHANGE: removed flag",
+			SHA:       "bbb",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+}
+
+func TestListCommits_truncated(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/compare/v1.0.0...HEAD", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_commits": 300, "commits": [{"sha": "aaa"}]}`)
+	})
+
+	c := newTestClient(t, mux)
+
+	_, err := c.ListCommits(context.Background(), "owner", "repo", "v1.0.0", "HEAD")
+	if err == nil {
+		t.Fatal("want: error, got: nil")
+	}
+
+	want := "owner/repo: GitHub returned only 1 of 300 commits in v1.0.0...HEAD, please specify narrower range"
+	if err.Error() != want {
+		t.Errorf("error want: %q, got: %q", want, err.Error())
+	}
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+var (
+	// ErrRepoNotFound is returned when the repository does not exist, or is not visible with the current credentials
+	ErrRepoNotFound = errors.New("repository not found")
+	// ErrTagNotFound is returned when the repository has no tag, or commit, of the given name
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrReleaseNotFound is returned when the tag has no release
+	ErrReleaseNotFound = errors.New("release not found")
+	// ErrRateLimited is returned when GitHub API rate limit is exceeded
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrUnauthorized is returned when the credentials are invalid or lack permission
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Error represents error returned by GitHub API, classified into Kind
+// errors.Is reports whether it is of the kind, and errors.As retrieves the original go-github error from it
+type Error struct {
+	// Kind is one of ErrRepoNotFound, ErrTagNotFound, ErrReleaseNotFound, ErrRateLimited and ErrUnauthorized
+	Kind  error
+	Owner string
+	Repo  string
+	// Tag is set for ErrTagNotFound and ErrReleaseNotFound
+	Tag string
+	// Authenticated reports whether the request was sent with credentials
+	Authenticated bool
+	// StatusCode and Message are of the API response, empty if unknown
+	StatusCode int
+	Message    string
+	// Reset is when rate limit is reset, zero if unknown
+	Reset time.Time
+	// Err is the original error
+	Err error
+}
+
+func (e *Error) Error() string {
+	switch e.Kind {
+	case ErrRepoNotFound:
+		if e.Repo == "" {
+			return fmt.Sprintf("%s: user or organization not found", e.Owner)
+		}
+		if e.Authenticated {
+			return fmt.Sprintf("%s: repository not found (check that the token has access to it)", e.repository())
+		}
+		return fmt.Sprintf("%s: repository not found (set GITHUB_TOKEN for private repos)", e.repository())
+	case ErrTagNotFound, ErrReleaseNotFound:
+		return fmt.Sprintf("%s@%s: %s", e.repository(), e.Tag, e.Kind)
+	case ErrRateLimited:
+		msg := "GitHub API rate limit exceeded"
+		if !e.Reset.IsZero() {
+			msg += ", reset at " + e.Reset.Local().Format(time.RFC3339)
+		}
+		if !e.Authenticated {
+			msg += " (set GITHUB_TOKEN to raise the limit)"
+		}
+		return msg
+	case ErrUnauthorized:
+		if e.StatusCode == http.StatusUnauthorized {
+			return fmt.Sprintf("GitHub API authentication failed: %s (check GITHUB_TOKEN or GitHub App credentials)", e.Message)
+		}
+		return fmt.Sprintf("%s: permission denied: %s (check that the token has the required scopes)", e.repository(), e.Message)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) repository() string {
+	if e.Repo == "" {
+		return e.Owner
+	}
+
+	return e.Owner + "/" + e.Repo
+}
+
+// wrapError classifies error returned by GitHub API into Error
+// notFound is the kind of 404 error, reported as ErrRepoNotFound instead if the repository itself does not exist.
+// 404 error is returned as is if notFound is nil, and so are errors not from GitHub API
+func (c *Client) wrapError(ctx context.Context, err error, owner, repo, tag string, notFound error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	e = &Error{
+		Owner:         owner,
+		Repo:          repo,
+		Authenticated: c.authenticated,
+		Err:           err,
+	}
+
+	var (
+		rateLimitErr *github.RateLimitError
+		abuseErr     *github.AbuseRateLimitError
+		respErr      *github.ErrorResponse
+	)
+
+	switch {
+	case errors.As(err, &rateLimitErr):
+		e.Kind = ErrRateLimited
+		e.Message = rateLimitErr.Message
+		e.Reset = rateLimitErr.Rate.Reset.Time
+	case errors.As(err, &abuseErr):
+		e.Kind = ErrRateLimited
+		e.Message = abuseErr.Message
+		if abuseErr.RetryAfter != nil {
+			e.Reset = time.Now().Add(*abuseErr.RetryAfter)
+		}
+	case errors.As(err, &respErr) && respErr.Response != nil:
+		e.StatusCode = respErr.Response.StatusCode
+		e.Message = respErr.Message
+
+		switch respErr.Response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			e.Kind = ErrUnauthorized
+		case http.StatusTooManyRequests:
+			e.Kind = ErrRateLimited
+		case http.StatusNotFound:
+			if notFound == nil {
+				return err
+			}
+
+			e.Kind = notFound
+
+			if notFound != ErrRepoNotFound {
+				e.Tag = tag
+
+				if !c.repositoryExists(ctx, owner, repo) {
+					e.Kind = ErrRepoNotFound
+					e.Tag = ""
+				}
+			}
+		default:
+			return err
+		}
+	default:
+		return err
+	}
+
+	return e
+}
+
+// repositoryExists reports whether the repository is visible, true if it cannot be determined
+func (c *Client) repositoryExists(ctx context.Context, owner, repo string) bool {
+	_, _, err := c.repositories.Get(ctx, owner, repo)
+
+	return !isNotFound(err)
+}
@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+func TestWrapError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"repo","owner":{"login":"owner"}}`)
+	})
+	mux.HandleFunc("/repos/owner/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	c := newTestClient(t, mux)
+
+	respErr := func(statusCode int, message string) error {
+		return &github.ErrorResponse{
+			Response: &http.Response{StatusCode: statusCode, Request: &http.Request{Method: http.MethodGet}},
+			Message:  message,
+		}
+	}
+
+	reset := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		err           error
+		repo          string
+		notFound      error
+		authenticated bool
+		kind          error
+		want          string
+	}{
+		{
+			err:      respErr(http.StatusNotFound, "Not Found"),
+			repo:     "repo",
+			notFound: ErrReleaseNotFound,
+			kind:     ErrReleaseNotFound,
+			want:     "owner/repo@v1.0.0: release not found",
+		},
+		{
+			err:      respErr(http.StatusNotFound, "Not Found"),
+			repo:     "repo",
+			notFound: ErrTagNotFound,
+			kind:     ErrTagNotFound,
+			want:     "owner/repo@v1.0.0: tag not found",
+		},
+		{
+			err:      respErr(http.StatusNotFound, "Not Found"),
+			repo:     "missing",
+			notFound: ErrReleaseNotFound,
+			kind:     ErrRepoNotFound,
+			want:     "owner/missing: repository not found (set GITHUB_TOKEN for private repos)",
+		},
+		{
+			err:           respErr(http.StatusNotFound, "Not Found"),
+			repo:          "missing",
+			notFound:      ErrRepoNotFound,
+			authenticated: true,
+			kind:          ErrRepoNotFound,
+			want:          "owner/missing: repository not found (check that the token has access to it)",
+		},
+		{
+			err:  respErr(http.StatusUnauthorized, "Bad credentials"),
+			repo: "repo",
+			kind: ErrUnauthorized,
+			want: "GitHub API authentication failed: Bad credentials (check GITHUB_TOKEN or GitHub App credentials)",
+		},
+		{
+			err:           respErr(http.StatusForbidden, "Resource not accessible by integration"),
+			repo:          "repo",
+			authenticated: true,
+			kind:          ErrUnauthorized,
+			want:          "owner/repo: permission denied: Resource not accessible by integration (check that the token has the required scopes)",
+		},
+		{
+			err: &github.RateLimitError{
+				Rate:     github.Rate{Reset: github.Timestamp{Time: reset}},
+				Response: &http.Response{StatusCode: http.StatusForbidden, Request: &http.Request{Method: http.MethodGet}},
+				Message:  "API rate limit exceeded",
+			},
+			repo:          "repo",
+			authenticated: true,
+			kind:          ErrRateLimited,
+			want:          "GitHub API rate limit exceeded, reset at " + reset.Local().Format(time.RFC3339),
+		},
+		{
+			err:  respErr(http.StatusTooManyRequests, "Too Many Requests"),
+			repo: "repo",
+			kind: ErrRateLimited,
+			want: "GitHub API rate limit exceeded (set GITHUB_TOKEN to raise the limit)",
+		},
+	}
+
+	for _, tc := range testcases {
+		c.authenticated = tc.authenticated
+
+		err := c.wrapError(context.Background(), tc.err, "owner", tc.repo, "v1.0.0", tc.notFound)
+
+		if !errors.Is(err, tc.kind) {
+			t.Errorf("want: %s, got: %#v", tc.kind, err)
+			continue
+		}
+
+		if !errors.Is(err, tc.err) {
+			t.Errorf("want: %#v wrapped, got: %#v", tc.err, err)
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+
+	// errors not classified are returned as is
+	for _, e := range []error{
+		respErr(http.StatusNotFound, "Not Found"),
+		respErr(http.StatusUnprocessableEntity, "Validation Failed"),
+		errors.New("connection refused"),
+	} {
+		if err := c.wrapError(context.Background(), e, "owner", "repo", "v1.0.0", nil); err != e {
+			t.Errorf("want: %#v, got: %#v", e, err)
+		}
+	}
+}
+
+func TestListTagsAndReleases_rateLimited(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Limit", "60")
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("X-Ratelimit-Reset", "1614556800")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"API rate limit exceeded for 203.0.113.1."}`)
+	}))
+
+	_, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", nil)
+	if err == nil {
+		t.Fatalf("want: error, got: nil")
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("want: %s, got: %s", ErrRateLimited, err)
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Errorf("want: rate limit error, got: %#v", err)
+	}
+
+	if want := "(set GITHUB_TOKEN to raise the limit)"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("want: %q at the end, got: %q", want, err.Error())
+	}
+}
+
+func TestListTagsAndReleases_unauthorized(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials"}`)
+	}))
+
+	_, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", nil)
+	if err == nil {
+		t.Fatalf("want: error, got: nil")
+	}
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("want: %s, got: %s", ErrUnauthorized, err)
+	}
+
+	if want := "GitHub API authentication failed: Bad credentials (check GITHUB_TOKEN or GitHub App credentials)"; err.Error() != want {
+		t.Errorf("want: %q, got: %q", want, err.Error())
+	}
+}
+
+func TestDescribeRelease_notFound(t *testing.T) {
+	c := newTestClient(t, newFakeReleasesAPI())
+
+	testcases := []struct {
+		repo string
+		tag  string
+		kind error
+		want string
+	}{
+		{
+			repo: "repo",
+			tag:  "v9.9.9",
+			kind: ErrReleaseNotFound,
+			want: "owner/repo@v9.9.9: release not found",
+		},
+		{
+			repo: "missing",
+			tag:  "v1.1.0",
+			kind: ErrRepoNotFound,
+			want: "owner/missing: repository not found (set GITHUB_TOKEN for private repos)",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := c.DescribeRelease(context.Background(), "owner", tc.repo, tc.tag)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if !errors.Is(err, tc.kind) || !isNotFound(err) {
+			t.Errorf("want: %s, got: %#v", tc.kind, err)
+		}
+
+		if err.Error() != tc.want {
+			t.Errorf("want: %q, got: %q", tc.want, err.Error())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"time"
+)
+
+// TagType represents kind of tags to list
+type TagType string
+
+const (
+	// TagTypeAll lists both tags with and without release
+	TagTypeAll TagType = "all"
+	// TagTypeTag lists only tags without release
+	TagTypeTag TagType = "tag"
+	// TagTypeRelease lists only tags with published release
+	TagTypeRelease TagType = "release"
+)
+
+// ListFilter represents conditions to narrow down tags and releases
+type ListFilter struct {
+	// Type is kind of tags to list, empty means TagTypeAll
+	Type TagType
+	// Since and Until restrict release creation time to [Since, Until], zero means unbounded
+	// Plain tags have no timestamp, so setting either implies TagTypeRelease
+	Since time.Time
+	Until time.Time
+	// Match reports whether the tag name should be listed, nil matches everything
+	Match func(name string) bool
+	// Limit is the maximum number of tags to list, 0 means unlimited
+	Limit int
+}
+
+func (f *ListFilter) releasesOnly() bool {
+	return f.Type == TagTypeRelease || !f.Since.IsZero() || !f.Until.IsZero()
+}
+
+func (f *ListFilter) matchName(name string) bool {
+	return f.Match == nil || f.Match(name)
+}
+
+func (f *ListFilter) matchTime(t time.Time) bool {
+	if !f.Since.IsZero() && t.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && t.After(f.Until) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// pagedRepositoriesService serves tags and releases in pages of 2 and records requested pages
+type pagedRepositoriesService struct {
+	fakeRepositoriesService
+
+	tags     []string
+	releases []*github.RepositoryRelease
+
+	tagPages     *[]int
+	releasePages *[]int
+	lookups      *[]string
+}
+
+func newPagedRepositoriesService() *pagedRepositoriesService {
+	release := func(tag string, day int, draft bool) *github.RepositoryRelease {
+		return &github.RepositoryRelease{
+			TagName:   github.String(tag),
+			Name:      github.String(tag),
+			Draft:     github.Bool(draft),
+			CreatedAt: &github.Timestamp{Time: time.Date(2020, 1, day, 0, 0, 0, 0, time.UTC)},
+		}
+	}
+
+	return &pagedRepositoriesService{
+		tags: []string{"v1.3.0", "v1.2.1", "v1.2.0", "v1.2.0-rc.1", "v1.1.0", "v1.0.0"},
+		// newest first, as GitHub API does
+		releases: []*github.RepositoryRelease{
+			release("v1.4.0", 30, true),
+			release("v1.3.0", 25, false),
+			release("v1.2.0", 20, false),
+			release("v1.1.0", 10, false),
+			release("v1.0.0", 1, false),
+		},
+		tagPages:     &[]int{},
+		releasePages: &[]int{},
+		lookups:      &[]string{},
+	}
+}
+
+func page(opt *github.ListOptions, n int) (int, int, int) {
+	p := opt.Page
+	if p == 0 {
+		p = 1
+	}
+
+	start, end := (p-1)*2, p*2
+	if end > n {
+		end = n
+	}
+
+	next := p + 1
+	if end >= n {
+		next = 0
+	}
+
+	return start, end, next
+}
+
+func (s pagedRepositoriesService) ListReleases(ctx context.Context, owner, repo string, opt *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	*s.releasePages = append(*s.releasePages, opt.Page)
+
+	start, end, next := page(opt, len(s.releases))
+
+	return s.releases[start:end], &github.Response{NextPage: next}, nil
+}
+
+// GetReleaseByTag returns published release of the tag, or 404 error as GitHub API does
+func (s pagedRepositoriesService) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error) {
+	*s.lookups = append(*s.lookups, tag)
+
+	for _, r := range s.releases {
+		if r.GetTagName() == tag && !r.GetDraft() {
+			return r, &github.Response{}, nil
+		}
+	}
+
+	return nil, nil, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "Not Found"}
+}
+
+func (s pagedRepositoriesService) ListTags(ctx context.Context, owner string, repo string, opt *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+	*s.tagPages = append(*s.tagPages, opt.Page)
+
+	start, end, next := page(opt, len(s.tags))
+
+	tags := []*github.RepositoryTag{}
+	for _, t := range s.tags[start:end] {
+		tags = append(tags, &github.RepositoryTag{Name: github.String(t)})
+	}
+
+	return tags, &github.Response{NextPage: next}, nil
+}
+
+func TestListTagsAndReleases_filter(t *testing.T) {
+	testcases := []struct {
+		filter       *ListFilter
+		want         []string
+		tagPages     []int
+		releasePages []int
+		lookups      []string
+	}{
+		{
+			filter:       nil,
+			want:         []string{"v1.3.0 R", "v1.2.1", "v1.2.0 R", "v1.2.0-rc.1", "v1.1.0 R", "v1.0.0 R"},
+			tagPages:     []int{0, 2, 3},
+			releasePages: []int{0, 2, 3},
+		},
+		{
+			filter:       &ListFilter{Limit: 3},
+			want:         []string{"v1.3.0 R", "v1.2.1", "v1.2.0 R"},
+			tagPages:     []int{0, 2},
+			releasePages: []int{0},
+			lookups:      []string{"v1.2.1", "v1.2.0"},
+		},
+		{
+			filter:       &ListFilter{Type: TagTypeTag, Limit: 1},
+			want:         []string{"v1.2.1"},
+			tagPages:     []int{0},
+			releasePages: []int{0},
+			lookups:      []string{"v1.2.1"},
+		},
+		{
+			filter:       &ListFilter{Type: TagTypeTag},
+			want:         []string{"v1.2.1", "v1.2.0-rc.1"},
+			tagPages:     []int{0, 2, 3},
+			releasePages: []int{0, 2, 3},
+		},
+		{
+			filter: &ListFilter{
+				Match: func(name string) bool { return strings.HasPrefix(name, "v1.2") },
+			},
+			want:         []string{"v1.2.1", "v1.2.0 R", "v1.2.0-rc.1"},
+			tagPages:     []int{0, 2, 3},
+			releasePages: []int{0, 2, 3},
+		},
+		{
+			filter:       &ListFilter{Type: TagTypeRelease},
+			want:         []string{"v1.3.0 R", "v1.2.0 R", "v1.1.0 R", "v1.0.0 R"},
+			tagPages:     []int{},
+			releasePages: []int{0, 2, 3},
+		},
+		{
+			filter:       &ListFilter{Type: TagTypeRelease, Limit: 1},
+			want:         []string{"v1.3.0 R"},
+			tagPages:     []int{},
+			releasePages: []int{0},
+		},
+		{
+			filter: &ListFilter{
+				Since: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+			want:         []string{"v1.3.0 R", "v1.2.0 R"},
+			tagPages:     []int{},
+			releasePages: []int{0, 2},
+		},
+		{
+			filter: &ListFilter{
+				Since: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC),
+				Until: time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+			want:         []string{"v1.2.0 R", "v1.1.0 R"},
+			tagPages:     []int{},
+			releasePages: []int{0, 2, 3},
+		},
+	}
+
+	for i, tc := range testcases {
+		s := newPagedRepositoriesService()
+		c := &Client{
+			repositories: s,
+		}
+
+		tags, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", tc.filter)
+		if err != nil {
+			t.Errorf("#%d want: no error, got: %s", i, err)
+			continue
+		}
+
+		got := []string{}
+		for _, tag := range tags {
+			if tag.Release != nil {
+				got = append(got, fmt.Sprintf("%s R", tag.Name))
+			} else {
+				got = append(got, tag.Name)
+			}
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d want: %q, got: %q", i, tc.want, got)
+		}
+
+		if !reflect.DeepEqual(*s.tagPages, tc.tagPages) {
+			t.Errorf("#%d tag pages want: %v, got: %v", i, tc.tagPages, *s.tagPages)
+		}
+
+		if !reflect.DeepEqual(*s.releasePages, tc.releasePages) {
+			t.Errorf("#%d release pages want: %v, got: %v", i, tc.releasePages, *s.releasePages)
+		}
+
+		lookups := tc.lookups
+		if lookups == nil {
+			lookups = []string{}
+		}
+
+		if !reflect.DeepEqual(*s.lookups, lookups) {
+			t.Errorf("#%d release lookups want: %q, got: %q", i, lookups, *s.lookups)
+		}
+	}
+}
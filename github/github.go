@@ -2,7 +2,10 @@ package github
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/google/go-github/v33/github"
@@ -17,52 +20,134 @@ const (
 
 type Release struct {
 	ArtifactURLs []string
+	Assets       []*Asset
 	Author       string
 	Body         string
 	Commit       string
 	CreatedAt    time.Time
+	Draft        bool
 	Name         string
+	Prerelease   bool
 	PublishedAt  time.Time
+	TarballURL   string
 	URL          string
+	ZipballURL   string
 }
 
 type Tag struct {
+	// Commit is SHA of the tagged commit, available only for tags listed by ListTagsAndReleases with tags
+	Commit  string
 	Name    string
 	Release *Release
 }
 
 type RepositoriesServiceInterface interface {
+	CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
+	DeleteRelease(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	DeleteReleaseAsset(ctx context.Context, owner, repo string, id int64) (*github.Response, error)
+	DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64, followRedirectsClient *http.Client) (io.ReadCloser, string, error)
+	EditRelease(ctx context.Context, owner, repo string, id int64, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error)
+	EditReleaseAsset(ctx context.Context, owner, repo string, id int64, release *github.ReleaseAsset) (*github.ReleaseAsset, *github.Response, error)
+	Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
 	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error)
+	GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error)
 	GetCommit(ctx context.Context, owner, repo, sha string) (*github.RepositoryCommit, *github.Response, error)
+	GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error)
+	List(ctx context.Context, user string, opts *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error)
+	ListByOrg(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error)
 	ListReleases(ctx context.Context, owner, repo string, opt *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error)
 	ListTags(ctx context.Context, owner string, repo string, opt *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error)
+	UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error)
+}
+
+type PullRequestsServiceInterface interface {
+	List(ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+}
+
+// APIInterface sends raw request to endpoints which go-github does not support
+type APIInterface interface {
+	NewRequest(method, urlStr string, body interface{}) (*http.Request, error)
+	Do(ctx context.Context, req *http.Request, v interface{}) (*github.Response, error)
+}
+
+type GitServiceInterface interface {
+	DeleteRef(ctx context.Context, owner string, repo string, ref string) (*github.Response, error)
+}
+
+type UsersServiceInterface interface {
+	Get(ctx context.Context, user string) (*github.User, *github.Response, error)
 }
 
 type ClientInterface interface {
+	CreateRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error)
+	DeleteRelease(ctx context.Context, owner, repo, tag string, deleteTag bool) error
+	DeleteReleaseAsset(ctx context.Context, owner, repo, tag, name string) error
 	DescribeRelease(ctx context.Context, owner, repo, tag string) (*Tag, error)
-	ListTagsAndReleases(ctx context.Context, owner, repo string) ([]*Tag, error)
+	EditRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error)
+	GenerateReleaseNotes(ctx context.Context, owner, repo, tag, previousTag string) (string, error)
+	LatestRelease(ctx context.Context, owner, repo string) (*Tag, error)
+	ListCommits(ctx context.Context, owner, repo, from, to string) ([]*Commit, error)
+	ListMergedPullRequests(ctx context.Context, owner, repo, from, to string) ([]*PullRequest, error)
+	ListRepositories(ctx context.Context, owner string, filter *RepositoryFilter) ([]*Repository, error)
+	ListTagsAndReleases(ctx context.Context, owner, repo string, filter *ListFilter) ([]*Tag, error)
+	OpenReleaseAsset(ctx context.Context, owner, repo string, asset *Asset, offset, length int64) (io.ReadCloser, error)
+	OpenSourceArchive(ctx context.Context, owner, repo, tag string, format ArchiveFormat) (io.ReadCloser, error)
+	UploadReleaseAsset(ctx context.Context, owner, repo, tag, filename string, clobber bool) (*Asset, error)
+	WalkReleases(ctx context.Context, owner, repo string, fn func(*Tag) error) error
+	WalkTags(ctx context.Context, owner, repo string, fn func(*Tag) error) error
 }
 
 // Client represents a wrapper of GitHub API client
 type Client struct {
+	api          APIInterface
+	git          GitServiceInterface
+	pullRequests PullRequestsServiceInterface
 	repositories RepositoriesServiceInterface
+	users        UsersServiceInterface
+	// download is used to download asset from URL GitHub API redirects to, which must not receive credentials
+	download *http.Client
+	// authenticated reports whether requests are sent with credentials, to give hint on error
+	authenticated bool
 }
 
 // NewClient creates new Client object
 func NewClient(accessToken string) *Client {
-	var hc *http.Client
-
 	if accessToken == "" {
-		hc = nil
-	} else {
-		ts := oauth2.StaticTokenSource(&oauth2.Token{
-			AccessToken: accessToken,
-		})
-		hc = oauth2.NewClient(oauth2.NoContext, ts)
+		return NewClientWithTokenSource(nil)
+	}
+
+	return NewClientWithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: accessToken,
+	}))
+}
+
+// NewClientWithTokenSource creates new Client object authenticated by the given token source
+// ts can be nil to access GitHub API anonymously
+func NewClientWithTokenSource(ts oauth2.TokenSource) *Client {
+	return NewClientWithTransport(ts, nil)
+}
+
+// NewClientWithTransport creates new Client object sending requests through the given transport, e.g. Recorder
+// ts can be nil to access GitHub API anonymously, and transport can be nil to use http.DefaultTransport
+func NewClientWithTransport(ts oauth2.TokenSource, transport http.RoundTripper) *Client {
+	hc := &http.Client{
+		Transport: transport,
+	}
+
+	if ts != nil {
+		hc = oauth2.NewClient(context.WithValue(oauth2.NoContext, oauth2.HTTPClient, hc), ts)
 	}
 
+	gc := github.NewClient(hc)
+
 	return &Client{
-		repositories: github.NewClient(hc).Repositories,
+		api:           gc,
+		git:           gc.Git,
+		pullRequests:  gc.PullRequests,
+		repositories:  gc.Repositories,
+		users:         gc.Users,
+		download:      &http.Client{Transport: transport},
+		authenticated: ts != nil,
 	}
 }
 
@@ -70,12 +155,12 @@ func NewClient(accessToken string) *Client {
 func (c *Client) DescribeRelease(ctx context.Context, owner, repo, tag string) (*Tag, error) {
 	release, err := c.getRelease(ctx, owner, repo, tag)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(ctx, err, owner, repo, tag, ErrReleaseNotFound)
 	}
 
 	commit, err := c.getTagCommit(ctx, owner, repo, tag)
 	if err != nil {
-		return nil, err
+		return nil, c.wrapError(ctx, err, owner, repo, tag, ErrTagNotFound)
 	}
 
 	var body, name string
@@ -93,9 +178,11 @@ func (c *Client) DescribeRelease(ctx context.Context, owner, repo, tag string) (
 	}
 
 	artifactURLs := []string{}
+	assets := []*Asset{}
 
 	for _, asset := range release.Assets {
 		artifactURLs = append(artifactURLs, *asset.BrowserDownloadURL)
+		assets = append(assets, toAsset(asset))
 	}
 
 	createdAt := *release.CreatedAt
@@ -105,6 +192,7 @@ func (c *Client) DescribeRelease(ctx context.Context, owner, repo, tag string) (
 		Name: *release.TagName,
 		Release: &Release{
 			ArtifactURLs: artifactURLs,
+			Assets:       assets,
 			Author:       *release.Author.Login,
 			Body:         body,
 			Commit:       *commit.SHA,
@@ -129,7 +217,11 @@ func (c *Client) DescribeRelease(ctx context.Context, owner, repo, tag string) (
 				publishedAt.Nanosecond(),
 				publishedAt.Location(),
 			),
-			URL: *release.HTMLURL,
+			TarballURL: release.GetTarballURL(),
+			URL:        *release.HTMLURL,
+			ZipballURL: release.GetZipballURL(),
+			Draft:      release.GetDraft(),
+			Prerelease: release.GetPrerelease(),
 		},
 	}, nil
 }
@@ -152,16 +244,39 @@ func (c *Client) getTagCommit(ctx context.Context, owner, repo, tag string) (*gi
 	return commit, nil
 }
 
-// ListTagsAndReleases retrieves all tags and releases of the given repository
-func (c *Client) ListTagsAndReleases(ctx context.Context, owner, repo string) ([]*Tag, error) {
-	tags, err := c.listTags(ctx, owner, repo)
-	if err != nil {
-		return []*Tag{}, err
+// ListTagsAndReleases retrieves tags and releases of the given repository
+// filter can be nil to retrieve all of them
+func (c *Client) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *ListFilter) ([]*Tag, error) {
+	if filter == nil {
+		filter = &ListFilter{}
 	}
 
-	releases, err := c.listReleases(ctx, owner, repo)
-	if err != nil {
-		return []*Tag{}, err
+	if filter.releasesOnly() {
+		return c.listReleaseTags(ctx, owner, repo, filter)
+	}
+
+	var (
+		releases  []*github.RepositoryRelease
+		allListed = true
+		err       error
+	)
+
+	if filter.Limit > 0 {
+		// only the first page of releases is listed, and releases of the other tags are looked up one by one,
+		// so that listing a few tags does not page through every release
+		var resp *github.Response
+
+		releases, resp, err = c.repositories.ListReleases(ctx, owner, repo, &github.ListOptions{PerPage: perPage})
+		if err != nil {
+			return []*Tag{}, c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
+		}
+
+		allListed = resp.NextPage == 0
+	} else {
+		releases, err = c.listReleases(ctx, owner, repo, func(*github.RepositoryRelease) bool { return true }, nil, 0)
+		if err != nil {
+			return []*Tag{}, err
+		}
 	}
 
 	releasesMap := map[string]*github.RepositoryRelease{}
@@ -170,52 +285,126 @@ func (c *Client) ListTagsAndReleases(ctx context.Context, owner, repo string) ([
 		releasesMap[*release.TagName] = release
 	}
 
-	ts := []*Tag{}
+	// findRelease returns release of the tag, or nil if the tag has no release
+	// Draft releases are not found by the lookup, as GetReleaseByTag API does not return them
+	findRelease := func(name string) (*github.RepositoryRelease, error) {
+		if r, ok := releasesMap[name]; ok || allListed {
+			return r, nil
+		}
 
-	for _, t := range tags {
-		var tag *Tag
+		r, err := c.getRelease(ctx, owner, repo, name)
+		if err != nil && !isNotFound(err) {
+			return nil, c.wrapError(ctx, err, owner, repo, name, nil)
+		}
 
-		if r, ok := releasesMap[*t.Name]; ok {
-			var name string
+		// nil is cached for tag without release
+		releasesMap[name] = r
 
-			if r.Name == nil {
-				name = ""
-			} else {
-				name = *r.Name
-			}
+		return r, nil
+	}
 
-			createdAt := *r.CreatedAt
+	tags, err := c.listTags(ctx, owner, repo, func(t *github.RepositoryTag) (bool, error) {
+		if !filter.matchName(*t.Name) {
+			return false, nil
+		}
 
-			tag = &Tag{
-				Name: *t.Name,
-				Release: &Release{
-					Name: name,
-					CreatedAt: time.Date(
-						createdAt.Year(),
-						createdAt.Month(),
-						createdAt.Day(),
-						createdAt.Hour(),
-						createdAt.Minute(),
-						createdAt.Second(),
-						createdAt.Nanosecond(),
-						createdAt.Location(),
-					),
-				},
-			}
+		if filter.Type != TagTypeTag {
+			return true, nil
+		}
+
+		r, err := findRelease(*t.Name)
+
+		return r == nil, err
+	}, filter.Limit)
+	if err != nil {
+		return []*Tag{}, err
+	}
+
+	ts := []*Tag{}
+
+	for _, t := range tags {
+		r, err := findRelease(*t.Name)
+		if err != nil {
+			return []*Tag{}, err
+		}
+
+		var tag *Tag
+
+		if r != nil {
+			tag = newReleaseTag(*t.Name, r)
 		} else {
 			tag = &Tag{
 				Name: *t.Name,
 			}
 		}
 
+		tag.Commit = t.GetCommit().GetSHA()
 		ts = append(ts, tag)
 	}
 
 	return ts, nil
 }
 
-// ListReleases lists all releases of the given repository
-func (c *Client) listReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error) {
+// listReleaseTags retrieves only tags which have published release
+// Releases are returned newest first, so that pagination can stop at the first release older than filter.Since
+func (c *Client) listReleaseTags(ctx context.Context, owner, repo string, filter *ListFilter) ([]*Tag, error) {
+	releases, err := c.listReleases(ctx, owner, repo, func(r *github.RepositoryRelease) bool {
+		if r.GetDraft() {
+			return false
+		}
+
+		return filter.matchName(r.GetTagName()) && filter.matchTime(r.GetCreatedAt().Time)
+	}, func(r *github.RepositoryRelease) bool {
+		return !filter.Since.IsZero() && r.GetCreatedAt().Time.Before(filter.Since)
+	}, filter.Limit)
+	if err != nil {
+		return []*Tag{}, err
+	}
+
+	ts := []*Tag{}
+
+	for _, r := range releases {
+		ts = append(ts, newReleaseTag(*r.TagName, r))
+	}
+
+	return ts, nil
+}
+
+func newReleaseTag(name string, r *github.RepositoryRelease) *Tag {
+	var releaseName string
+
+	if r.Name == nil {
+		releaseName = ""
+	} else {
+		releaseName = *r.Name
+	}
+
+	createdAt := *r.CreatedAt
+
+	return &Tag{
+		Name: name,
+		Release: &Release{
+			Name: releaseName,
+			CreatedAt: time.Date(
+				createdAt.Year(),
+				createdAt.Month(),
+				createdAt.Day(),
+				createdAt.Hour(),
+				createdAt.Minute(),
+				createdAt.Second(),
+				createdAt.Nanosecond(),
+				createdAt.Location(),
+			),
+			Draft:      r.GetDraft(),
+			Prerelease: r.GetPrerelease(),
+		},
+	}
+}
+
+// ListReleases lists releases of the given repository which satisfy keep
+// Pagination stops once limit releases are collected (0 means unlimited),
+// or stop returns true for a release
+func (c *Client) listReleases(ctx context.Context, owner, repo string, keep, stop func(*github.RepositoryRelease) bool, limit int) ([]*github.RepositoryRelease, error) {
 	allReleases := []*github.RepositoryRelease{}
 
 	listOpts := &github.ListOptions{
@@ -225,10 +414,24 @@ func (c *Client) listReleases(ctx context.Context, owner, repo string) ([]*githu
 	for {
 		releases, resp, err := c.repositories.ListReleases(ctx, owner, repo, listOpts)
 		if err != nil {
-			return []*github.RepositoryRelease{}, err
+			return []*github.RepositoryRelease{}, c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
 		}
 
-		allReleases = append(allReleases, releases...)
+		for _, release := range releases {
+			if stop != nil && stop(release) {
+				return allReleases, nil
+			}
+
+			if !keep(release) {
+				continue
+			}
+
+			allReleases = append(allReleases, release)
+
+			if limit > 0 && len(allReleases) >= limit {
+				return allReleases, nil
+			}
+		}
 
 		if resp.NextPage == 0 {
 			break
@@ -240,8 +443,9 @@ func (c *Client) listReleases(ctx context.Context, owner, repo string) ([]*githu
 	return allReleases, nil
 }
 
-// ListTags lists all tags of the given repository
-func (c *Client) listTags(ctx context.Context, owner, repo string) ([]*github.RepositoryTag, error) {
+// ListTags lists tags of the given repository which satisfy keep
+// Pagination stops once limit tags are collected (0 means unlimited), or keep returns error
+func (c *Client) listTags(ctx context.Context, owner, repo string, keep func(*github.RepositoryTag) (bool, error), limit int) ([]*github.RepositoryTag, error) {
 	allTags := []*github.RepositoryTag{}
 
 	listOpts := &github.ListOptions{
@@ -251,10 +455,25 @@ func (c *Client) listTags(ctx context.Context, owner, repo string) ([]*github.Re
 	for {
 		tags, resp, err := c.repositories.ListTags(ctx, owner, repo, listOpts)
 		if err != nil {
-			return []*github.RepositoryTag{}, err
+			return []*github.RepositoryTag{}, c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
 		}
 
-		allTags = append(allTags, tags...)
+		for _, tag := range tags {
+			ok, err := keep(tag)
+			if err != nil {
+				return []*github.RepositoryTag{}, err
+			}
+
+			if !ok {
+				continue
+			}
+
+			allTags = append(allTags, tag)
+
+			if limit > 0 && len(allTags) >= limit {
+				return allTags, nil
+			}
+		}
 
 		if resp.NextPage == 0 {
 			break
@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 	"time"
@@ -18,6 +20,8 @@ func (s fakeRepositoriesService) GetReleaseByTag(ctx context.Context, owner, rep
 	login := "dtan4"
 	name := "v1"
 	htmlURL := "https://github.com/owner/repo/releases/tag/v1"
+	tarballURL := "https://api.github.com/repos/owner/repo/tarball/v1"
+	zipballURL := "https://api.github.com/repos/owner/repo/zipball/v1"
 
 	return &github.RepositoryRelease{
 		Assets: []*github.ReleaseAsset{
@@ -29,11 +33,13 @@ func (s fakeRepositoriesService) GetReleaseByTag(ctx context.Context, owner, rep
 			Login: &login,
 		},
 		Body:        &body,
-		CreatedAt:   &github.Timestamp{time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC)},
+		CreatedAt:   &github.Timestamp{Time: time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC)},
 		HTMLURL:     &htmlURL,
 		Name:        &name,
-		PublishedAt: &github.Timestamp{time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC)},
+		PublishedAt: &github.Timestamp{Time: time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC)},
 		TagName:     &tagName,
+		TarballURL:  &tarballURL,
+		ZipballURL:  &zipballURL,
 	}, &github.Response{}, nil
 }
 
@@ -45,6 +51,13 @@ func (s fakeRepositoriesService) GetCommit(ctx context.Context, owner, repo, sha
 	}, &github.Response{}, nil
 }
 
+func (s fakeRepositoriesService) Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error) {
+	return &github.Repository{
+		Name:  github.String(repo),
+		Owner: &github.User{Login: github.String(owner)},
+	}, &github.Response{}, nil
+}
+
 func (s fakeRepositoriesService) ListReleases(ctx context.Context, owner, repo string, opt *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
 	tag_v1_13_2_beta_0 := "v1.13.2-beta.0"
 	tag_v1_13_1 := "v1.13.1"
@@ -54,12 +67,12 @@ func (s fakeRepositoriesService) ListReleases(ctx context.Context, owner, repo s
 		&github.RepositoryRelease{
 			TagName:   &tag_v1_13_2_beta_0,
 			Name:      nil,
-			CreatedAt: &github.Timestamp{time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC)},
+			CreatedAt: &github.Timestamp{Time: time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC)},
 		},
 		&github.RepositoryRelease{
 			TagName:   &tag_v1_13_1,
 			Name:      &release_v1_13_1,
-			CreatedAt: &github.Timestamp{time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC)},
+			CreatedAt: &github.Timestamp{Time: time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC)},
 		},
 	}, &github.Response{}, nil
 }
@@ -78,6 +91,9 @@ func (s fakeRepositoriesService) ListTags(ctx context.Context, owner string, rep
 		},
 		&github.RepositoryTag{
 			Name: &tag_v1_13_1,
+			Commit: &github.Commit{
+				SHA: github.String("856abeb2b507fc1db16dcaea938775ff938a5355"),
+			},
 		},
 	}, &github.Response{}, nil
 }
@@ -114,13 +130,20 @@ func TestDescribeRelease(t *testing.T) {
 			ArtifactURLs: []string{
 				"https://github.com/owner/repo/releases/download/v1/darwin.tar.gz",
 			},
+			Assets: []*Asset{
+				{
+					URL: "https://github.com/owner/repo/releases/download/v1/darwin.tar.gz",
+				},
+			},
 			Author:      "dtan4",
 			Body:        "The quick brown fox jumps over the lazy dog",
 			Commit:      "856abeb2b507fc1db16dcaea938775ff938a5355",
 			CreatedAt:   time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC),
 			Name:        "v1",
 			PublishedAt: time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC),
+			TarballURL:  "https://api.github.com/repos/owner/repo/tarball/v1",
 			URL:         "https://github.com/owner/repo/releases/tag/v1",
+			ZipballURL:  "https://api.github.com/repos/owner/repo/zipball/v1",
 		},
 	}
 
@@ -155,7 +178,8 @@ func TestListTagsAndReleases(t *testing.T) {
 			},
 		},
 		&Tag{
-			Name: "v1.13.1",
+			Commit: "856abeb2b507fc1db16dcaea938775ff938a5355",
+			Name:   "v1.13.1",
 			Release: &Release{
 				Name:      "v1.13.1",
 				CreatedAt: time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC),
@@ -163,7 +187,7 @@ func TestListTagsAndReleases(t *testing.T) {
 		},
 	}
 
-	got, err := c.ListTagsAndReleases(context.Background(), owner, repo)
+	got, err := c.ListTagsAndReleases(context.Background(), owner, repo, nil)
 	if err != nil {
 		t.Errorf("want no error, got: %#v", err)
 	}
@@ -178,3 +202,72 @@ func TestListTagsAndReleases(t *testing.T) {
 		}
 	}
 }
+
+// newFakeReleasesAPI returns handler serving releases and tags of owner/repo like GitHub API
+// Tags are listed over 2 pages, and the other repositories do not exist
+func newFakeReleasesAPI() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"repo","owner":{"login":"owner"}}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+  {"id":3,"tag_name":"v1.2.0","name":"v1.2.0","draft":true,"created_at":"2021-03-10T09:00:00Z"},
+  {"id":2,"tag_name":"v1.1.0","name":"v1.1.0","created_at":"2021-03-05T09:00:00Z"},
+  {"id":1,"tag_name":"v1.0.0","name":"v1.0.0","created_at":"2021-03-01T09:00:00Z"}
+]`)
+	})
+	mux.HandleFunc("/repos/owner/repo/tags", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"name":"v1.0.0","commit":{"sha":"aaaa1111"}}]`)
+			return
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/owner/repo/tags?per_page=100&page=2>; rel="next"`, r.Host))
+		fmt.Fprint(w, `[{"name":"v1.1.0","commit":{"sha":"bbbb2222"}},{"name":"v1.0.1","commit":{"sha":"cccc3333"}}]`)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases/tags/v1.1.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+  "id":2,
+  "tag_name":"v1.1.0",
+  "name":"v1.1.0",
+  "html_url":"https://github.com/owner/repo/releases/tag/v1.1.0",
+  "tarball_url":"https://api.github.com/repos/owner/repo/tarball/v1.1.0",
+  "zipball_url":"https://api.github.com/repos/owner/repo/zipball/v1.1.0",
+  "body":"release notes",
+  "created_at":"2021-03-05T09:00:00Z",
+  "published_at":"2021-03-05T10:00:00Z",
+  "author":{"login":"dtan4"},
+  "assets":[{"id":21,"name":"tool_1.1.0_linux_amd64.tar.gz","size":1024,"browser_download_url":"https://github.com/owner/repo/releases/download/v1.1.0/tool_1.1.0_linux_amd64.tar.gz"}]
+}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/commits/v1.1.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha":"bbbb2222"}`)
+	})
+
+	return mux
+}
+
+func TestListTagsAndReleases_pagination(t *testing.T) {
+	c := newTestClient(t, newFakeReleasesAPI())
+
+	tags, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", nil)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	got := []string{}
+	for _, tag := range tags {
+		if tag.Release != nil {
+			got = append(got, fmt.Sprintf("%s R %s", tag.Name, tag.Commit))
+		} else {
+			got = append(got, fmt.Sprintf("%s %s", tag.Name, tag.Commit))
+		}
+	}
+
+	want := []string{"v1.1.0 R bbbb2222", "v1.0.1 cccc3333", "v1.0.0 R aaaa1111"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// PullRequest represents merged pull request
+type PullRequest struct {
+	Author   string
+	Labels   []string
+	MergedAt time.Time
+	Number   int
+	Title    string
+	URL      string
+}
+
+// ListMergedPullRequests retrieves merged pull requests which introduced commits between from and to
+// Pull requests are sorted by merged time, oldest first
+// Pull requests are matched by their merge commit, which is in the range whether they are merged, squashed or rebased
+func (c *Client) ListMergedPullRequests(ctx context.Context, owner, repo, from, to string) ([]*PullRequest, error) {
+	commits, err := c.compareCommits(ctx, owner, repo, from, to)
+	if err != nil {
+		return []*PullRequest{}, err
+	}
+
+	if len(commits) == 0 {
+		return []*PullRequest{}, nil
+	}
+
+	shas := map[string]bool{}
+
+	for _, commit := range commits {
+		shas[commit.GetSHA()] = true
+	}
+
+	// merge commit is created when pull request is merged,
+	// so pull requests last updated before the oldest commit in the range are not merged into it
+	since := oldestCommitDate(commits)
+
+	pullRequests := []*PullRequest{}
+
+	listOpts := &github.PullRequestListOptions{
+		State:     "closed",
+		Sort:      "updated",
+		Direction: "desc",
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+		},
+	}
+
+	for {
+		prs, resp, err := c.pullRequests.List(ctx, owner, repo, listOpts)
+		if err != nil {
+			return []*PullRequest{}, c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
+		}
+
+		for _, pr := range prs {
+			if !since.IsZero() && pr.GetUpdatedAt().Before(since) {
+				return sortPullRequests(pullRequests), nil
+			}
+
+			if pr.MergedAt == nil || !shas[pr.GetMergeCommitSHA()] {
+				continue
+			}
+
+			labels := []string{}
+
+			for _, l := range pr.Labels {
+				labels = append(labels, l.GetName())
+			}
+
+			pullRequests = append(pullRequests, &PullRequest{
+				Author:   pr.GetUser().GetLogin(),
+				Labels:   labels,
+				MergedAt: pr.GetMergedAt(),
+				Number:   pr.GetNumber(),
+				Title:    pr.GetTitle(),
+				URL:      pr.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return sortPullRequests(pullRequests), nil
+}
+
+// oldestCommitDate returns the oldest committed time of the commits, or zero if any of them is unknown
+func oldestCommitDate(commits []*github.RepositoryCommit) time.Time {
+	var oldest time.Time
+
+	for _, commit := range commits {
+		d := commit.GetCommit().GetCommitter().GetDate()
+		if d.IsZero() {
+			return time.Time{}
+		}
+
+		if oldest.IsZero() || d.Before(oldest) {
+			oldest = d
+		}
+	}
+
+	return oldest
+}
+
+func sortPullRequests(prs []*PullRequest) []*PullRequest {
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].MergedAt.Before(prs[j].MergedAt)
+	})
+
+	return prs
+}
+
+// GenerateReleaseNotes generates release notes of tag by GitHub
+// previousTag can be empty to let GitHub choose the previous release
+// This endpoint is not supported by go-github v33, so request is built by hand
+func (c *Client) GenerateReleaseNotes(ctx context.Context, owner, repo, tag, previousTag string) (string, error) {
+	body := struct {
+		TagName         string `json:"tag_name"`
+		PreviousTagName string `json:"previous_tag_name,omitempty"`
+	}{
+		TagName:         tag,
+		PreviousTagName: previousTag,
+	}
+
+	req, err := c.api.NewRequest("POST", fmt.Sprintf("repos/%s/%s/releases/generate-notes", owner, repo), body)
+	if err != nil {
+		return "", err
+	}
+
+	var notes struct {
+		Name string `json:"name"`
+		Body string `json:"body"`
+	}
+
+	if _, err := c.api.Do(ctx, req, &notes); err != nil {
+		return "", c.wrapError(ctx, err, owner, repo, tag, ErrRepoNotFound)
+	}
+
+	return notes.Body, nil
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListMergedPullRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/compare/v1.0.0...main", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_commits": 4, "commits": [
+  {"sha": "aaa", "commit": {"committer": {"date": "2021-03-01T00:00:00Z"}}},
+  {"sha": "bbb", "commit": {"committer": {"date": "2021-03-01T00:00:00Z"}}},
+  {"sha": "ccc", "commit": {"committer": {"date": "2021-03-02T00:00:00Z"}}},
+  {"sha": "ddd", "commit": {"committer": {"date": "2021-03-03T00:00:00Z"}}}
+]}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != "closed" || q.Get("sort") != "updated" || q.Get("direction") != "desc" {
+			t.Errorf("want: closed pull requests sorted by updated time desc, got: %s", r.URL.RawQuery)
+		}
+
+		if q.Get("page") == "2" {
+			fmt.Fprint(w, `[
+  {"number": 1, "title": "Fix bug", "user": {"login": "bob"}, "labels": [], "merged_at": "2021-03-01T00:00:00Z", "updated_at": "2021-03-01T00:00:00Z", "merge_commit_sha": "bbb", "html_url": "https://github.com/owner/repo/pull/1"},
+  {"number": 0, "title": "Released before", "user": {"login": "bob"}, "merged_at": "2021-02-01T00:00:00Z", "updated_at": "2021-02-01T00:00:00Z", "merge_commit_sha": "zzz"}
+]`)
+			return
+		}
+
+		if q.Get("page") == "3" {
+			t.Error("pull requests updated before the range must not be listed")
+		}
+
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+		fmt.Fprint(w, `[
+  {"number": 4, "title": "Merged into other branch", "user": {"login": "alice"}, "merged_at": "2021-03-04T00:00:00Z", "updated_at": "2021-03-04T00:00:00Z", "merge_commit_sha": "eee"},
+  {"number": 3, "title": "Closed without merge", "user": {"login": "bob"}, "updated_at": "2021-03-03T00:00:00Z"},
+  {"number": 2, "title": "Add feature", "user": {"login": "alice"}, "labels": [{"name": "feature"}], "merged_at": "2021-03-02T00:00:00Z", "updated_at": "2021-03-02T00:00:00Z", "merge_commit_sha": "ccc", "html_url": "https://github.com/owner/repo/pull/2"}
+]`)
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.ListMergedPullRequests(context.Background(), "owner", "repo", "v1.0.0", "main")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := []*PullRequest{
+		{
+			Author:   "bob",
+			Labels:   []string{},
+			MergedAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Number:   1,
+			Title:    "Fix bug",
+			URL:      "https://github.com/owner/repo/pull/1",
+		},
+		{
+			Author:   "alice",
+			Labels:   []string{"feature"},
+			MergedAt: time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC),
+			Number:   2,
+			Title:    "Add feature",
+			URL:      "https://github.com/owner/repo/pull/2",
+		},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want: %d pull requests, got: %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("want: %#v, got: %#v", want[i], got[i])
+		}
+	}
+}
+
+func TestGenerateReleaseNotes(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/generate-notes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method want: POST, got: %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[string]interface{}{
+			"tag_name":          "v1.1.0",
+			"previous_tag_name": "v1.0.0",
+		}
+
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("request body want: %#v, got: %#v", want, body)
+		}
+
+		fmt.Fprint(w, `{"name": "v1.1.0", "body": "## What's Changed\n* Add feature by @alice"}`)
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.GenerateReleaseNotes(context.Background(), "owner", "repo", "v1.1.0", "v1.0.0")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := "## What's Changed\n* Add feature by @alice"
+	if got != want {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+}
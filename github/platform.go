@@ -0,0 +1,244 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNoMatchingAsset is returned when no asset is built for the platform
+	ErrNoMatchingAsset = errors.New("no asset matches")
+	// ErrAmbiguousAsset is returned when more than one asset is equally suitable for the platform
+	ErrAmbiguousAsset = errors.New("multiple assets match")
+)
+
+// Platform represents OS and architecture in GOOS/GOARCH notation
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+func (p Platform) String() string {
+	return p.OS + "/" + p.Arch
+}
+
+// AssetSelectionError is returned by SelectAsset with the assets considered
+type AssetSelectionError struct {
+	Err        error
+	Platform   Platform
+	Candidates []string
+}
+
+func (e *AssetSelectionError) Error() string {
+	if len(e.Candidates) == 0 {
+		return fmt.Sprintf("%s %s", e.Err, e.Platform)
+	}
+
+	if errors.Is(e.Err, ErrAmbiguousAsset) {
+		return fmt.Sprintf("%s %s: %s", e.Err, e.Platform, strings.Join(e.Candidates, ", "))
+	}
+
+	return fmt.Sprintf("%s %s among %s", e.Err, e.Platform, strings.Join(e.Candidates, ", "))
+}
+
+func (e *AssetSelectionError) Unwrap() error {
+	return e.Err
+}
+
+type alias struct {
+	name  string
+	value string
+	re    *regexp.Regexp
+}
+
+var (
+	osAliases = newAliases(map[string][]string{
+		"darwin":  {"darwin", "macos", "mac", "osx", "apple"},
+		"freebsd": {"freebsd"},
+		"linux":   {"linux"},
+		"windows": {"windows", "win", "win32", "win64"},
+	})
+
+	// "universal" is macOS fat binary which runs on any architecture
+	archAliases = newAliases(map[string][]string{
+		"386":       {"386", "i386", "i686", "x86", "32bit", "win32"},
+		"amd64":     {"amd64", "x86_64", "x86-64", "x64", "64bit", "win64"},
+		"arm":       {"arm", "armv6", "armv6l", "armv7", "armv7l", "armhf", "armel"},
+		"arm64":     {"arm64", "aarch64", "armv8"},
+		"universal": {"universal", "universal2"},
+	})
+
+	// statically linked binary runs regardless of libc on the host
+	staticAliases = newAliases(map[string][]string{
+		"static": {"musl", "static"},
+	})
+
+	// assets which are not the program itself
+	ignoredAssetSuffixes = []string{
+		".asc", ".cert", ".crt", ".json", ".jsonl", ".md5", ".pem", ".sbom", ".sha1", ".sha256", ".sha256sum", ".sha512", ".sig", ".spdx", ".txt", ".yaml", ".yml",
+		// packages for system package managers
+		".apk", ".deb", ".dmg", ".msi", ".pkg", ".rpm",
+	}
+
+	// preferred format on each OS, the latter is more preferred
+	archiveFormats = map[string][]string{
+		"windows": {".tar.gz", ".tgz", ".exe", ".zip"},
+		"":        {".zip", ".tar.xz", ".txz", ".tar.gz", ".tgz"},
+	}
+)
+
+// newAliases flattens alias table, longer alias first so that "x86_64" is not taken for "x86"
+func newAliases(m map[string][]string) []alias {
+	as := []alias{}
+
+	for value, names := range m {
+		for _, name := range names {
+			as = append(as, alias{name: name, value: value, re: wordRegexp(name)})
+		}
+	}
+
+	sort.Slice(as, func(i, j int) bool {
+		if len(as[i].name) != len(as[j].name) {
+			return len(as[i].name) > len(as[j].name)
+		}
+
+		return as[i].name < as[j].name
+	})
+
+	return as
+}
+
+// NewPlatform normalizes the given OS and architecture names (e.g. macos, x86_64) into Platform
+func NewPlatform(os, arch string) (Platform, error) {
+	o, ok := lookupAlias(osAliases, strings.ToLower(os))
+	if !ok {
+		return Platform{}, fmt.Errorf("unknown OS: %s", os)
+	}
+
+	a, ok := lookupAlias(archAliases, strings.ToLower(arch))
+	if !ok || a == "universal" {
+		return Platform{}, fmt.Errorf("unknown architecture: %s", arch)
+	}
+
+	return Platform{OS: o, Arch: a}, nil
+}
+
+func lookupAlias(as []alias, name string) (string, bool) {
+	for _, a := range as {
+		if a.name == name {
+			return a.value, true
+		}
+	}
+
+	return "", false
+}
+
+// wordRegexp matches w separated by non-alphanumeric characters, e.g. "linux" in "tool-linux-amd64"
+func wordRegexp(w string) *regexp.Regexp {
+	return regexp.MustCompile(`(^|[^a-z0-9])` + regexp.QuoteMeta(w) + `([^a-z0-9]|$)`)
+}
+
+// detectAlias finds the longest alias appearing in name as a separate word
+func detectAlias(as []alias, name string) string {
+	for _, a := range as {
+		if a.re.MatchString(name) {
+			return a.value
+		}
+	}
+
+	return ""
+}
+
+// scoreAsset rates how suitable the asset of the given name is for the platform
+// false is returned if the asset is not for the platform at all
+func scoreAsset(name string, p Platform) (int, bool) {
+	name = strings.ToLower(name)
+
+	for _, s := range ignoredAssetSuffixes {
+		if strings.HasSuffix(name, s) {
+			return 0, false
+		}
+	}
+
+	if detectAlias(osAliases, name) != p.OS {
+		return 0, false
+	}
+
+	score := 0
+
+	// architecture matters most, then libc, then archive format
+	switch detectAlias(archAliases, name) {
+	case p.Arch:
+		score += 100
+	case "universal":
+		if p.OS != "darwin" {
+			return 0, false
+		}
+		score += 50
+	case "":
+		// single architecture build
+	default:
+		return 0, false
+	}
+
+	if p.OS == "linux" && detectAlias(staticAliases, name) != "" {
+		score += 10
+	}
+
+	formats, ok := archiveFormats[p.OS]
+	if !ok {
+		formats = archiveFormats[""]
+	}
+
+	for i, f := range formats {
+		if strings.HasSuffix(name, f) {
+			score += i + 1
+			break
+		}
+	}
+
+	return score, true
+}
+
+// SelectAsset picks the asset built for the given platform from its name (e.g. tool_1.2.3_linux_amd64.tar.gz)
+// AssetSelectionError wrapping ErrNoMatchingAsset or ErrAmbiguousAsset is returned if no single asset is the best
+func SelectAsset(assets []*Asset, p Platform) (*Asset, error) {
+	var (
+		best       *Asset
+		bestScore  = -1
+		candidates = []string{}
+	)
+
+	for _, a := range assets {
+		score, ok := scoreAsset(a.Name, p)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case score > bestScore:
+			best, bestScore = a, score
+			candidates = []string{a.Name}
+		case score == bestScore:
+			candidates = append(candidates, a.Name)
+		}
+	}
+
+	if best == nil {
+		names := make([]string, 0, len(assets))
+		for _, a := range assets {
+			names = append(names, a.Name)
+		}
+
+		return nil, &AssetSelectionError{Err: ErrNoMatchingAsset, Platform: p, Candidates: names}
+	}
+
+	if len(candidates) > 1 {
+		return nil, &AssetSelectionError{Err: ErrAmbiguousAsset, Platform: p, Candidates: candidates}
+	}
+
+	return best, nil
+}
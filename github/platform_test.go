@@ -0,0 +1,176 @@
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newAssets(names ...string) []*Asset {
+	assets := make([]*Asset, 0, len(names))
+
+	for _, name := range names {
+		assets = append(assets, &Asset{Name: name})
+	}
+
+	return assets
+}
+
+func TestNewPlatform(t *testing.T) {
+	testcases := []struct {
+		os      string
+		arch    string
+		want    Platform
+		wantErr bool
+	}{
+		{os: "linux", arch: "amd64", want: Platform{OS: "linux", Arch: "amd64"}},
+		{os: "macOS", arch: "aarch64", want: Platform{OS: "darwin", Arch: "arm64"}},
+		{os: "windows", arch: "x86_64", want: Platform{OS: "windows", Arch: "amd64"}},
+		{os: "plan9", arch: "amd64", wantErr: true},
+		{os: "linux", arch: "universal", wantErr: true},
+	}
+
+	for _, tc := range testcases {
+		got, err := NewPlatform(tc.os, tc.arch)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%s/%s: want: error, got: nil", tc.os, tc.arch)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s/%s: want: no error, got: %s", tc.os, tc.arch, err)
+			continue
+		}
+
+		if got != tc.want {
+			t.Errorf("want: %s, got: %s", tc.want, got)
+		}
+	}
+}
+
+func TestSelectAsset(t *testing.T) {
+	goreleaser := newAssets(
+		"checksums.txt",
+		"tool_1.2.3_darwin_amd64.tar.gz",
+		"tool_1.2.3_darwin_arm64.tar.gz",
+		"tool_1.2.3_linux_386.tar.gz",
+		"tool_1.2.3_linux_amd64.tar.gz",
+		"tool_1.2.3_linux_amd64.tar.gz.sig",
+		"tool_1.2.3_linux_arm64.tar.gz",
+		"tool_1.2.3_linux_armv7.tar.gz",
+		"tool_1.2.3_linux_amd64.deb",
+		"tool_1.2.3_windows_amd64.zip",
+	)
+
+	rust := newAssets(
+		"tool-v1.2.3-aarch64-apple-darwin.tar.gz",
+		"tool-v1.2.3-x86_64-apple-darwin.tar.gz",
+		"tool-v1.2.3-x86_64-pc-windows-msvc.zip",
+		"tool-v1.2.3-x86_64-unknown-linux-gnu.tar.gz",
+		"tool-v1.2.3-x86_64-unknown-linux-musl.tar.gz",
+		"tool-v1.2.3-x86_64-unknown-linux-musl.tar.gz.sha256",
+	)
+
+	testcases := []struct {
+		assets   []*Asset
+		platform Platform
+		want     string
+	}{
+		{assets: goreleaser, platform: Platform{OS: "linux", Arch: "amd64"}, want: "tool_1.2.3_linux_amd64.tar.gz"},
+		{assets: goreleaser, platform: Platform{OS: "linux", Arch: "386"}, want: "tool_1.2.3_linux_386.tar.gz"},
+		{assets: goreleaser, platform: Platform{OS: "linux", Arch: "arm"}, want: "tool_1.2.3_linux_armv7.tar.gz"},
+		{assets: goreleaser, platform: Platform{OS: "darwin", Arch: "arm64"}, want: "tool_1.2.3_darwin_arm64.tar.gz"},
+		{assets: goreleaser, platform: Platform{OS: "windows", Arch: "amd64"}, want: "tool_1.2.3_windows_amd64.zip"},
+		{assets: rust, platform: Platform{OS: "linux", Arch: "amd64"}, want: "tool-v1.2.3-x86_64-unknown-linux-musl.tar.gz"},
+		{assets: rust, platform: Platform{OS: "darwin", Arch: "arm64"}, want: "tool-v1.2.3-aarch64-apple-darwin.tar.gz"},
+		{assets: rust, platform: Platform{OS: "windows", Arch: "amd64"}, want: "tool-v1.2.3-x86_64-pc-windows-msvc.zip"},
+		{
+			assets:   newAssets("tool-darwin-universal.zip", "tool-darwin-amd64.zip"),
+			platform: Platform{OS: "darwin", Arch: "arm64"},
+			want:     "tool-darwin-universal.zip",
+		},
+		{
+			// single architecture release
+			assets:   newAssets("tool-linux", "tool-macos", "tool-windows.exe"),
+			platform: Platform{OS: "windows", Arch: "amd64"},
+			want:     "tool-windows.exe",
+		},
+		{
+			assets:   newAssets("tool-linux-amd64", "tool-linux-amd64.tar.gz"),
+			platform: Platform{OS: "linux", Arch: "amd64"},
+			want:     "tool-linux-amd64.tar.gz",
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := SelectAsset(tc.assets, tc.platform)
+		if err != nil {
+			t.Errorf("%s: want: no error, got: %s", tc.platform, err)
+			continue
+		}
+
+		if got.Name != tc.want {
+			t.Errorf("%s: want: %s, got: %s", tc.platform, tc.want, got.Name)
+		}
+	}
+}
+
+func TestSelectAsset_error(t *testing.T) {
+	testcases := []struct {
+		assets         []*Asset
+		platform       Platform
+		wantErr        error
+		wantCandidates []string
+		wantMessage    string
+	}{
+		{
+			assets:         newAssets("tool-linux-amd64.tar.gz", "other-linux-amd64.tar.gz", "tool-linux-arm64.tar.gz"),
+			platform:       Platform{OS: "linux", Arch: "amd64"},
+			wantErr:        ErrAmbiguousAsset,
+			wantCandidates: []string{"tool-linux-amd64.tar.gz", "other-linux-amd64.tar.gz"},
+			wantMessage:    "multiple assets match linux/amd64: tool-linux-amd64.tar.gz, other-linux-amd64.tar.gz",
+		},
+		{
+			assets:         newAssets("tool-linux-amd64.tar.gz", "checksums.txt"),
+			platform:       Platform{OS: "darwin", Arch: "arm64"},
+			wantErr:        ErrNoMatchingAsset,
+			wantCandidates: []string{"tool-linux-amd64.tar.gz", "checksums.txt"},
+			wantMessage:    "no asset matches darwin/arm64 among tool-linux-amd64.tar.gz, checksums.txt",
+		},
+		{
+			assets:         newAssets(),
+			platform:       Platform{OS: "linux", Arch: "amd64"},
+			wantErr:        ErrNoMatchingAsset,
+			wantCandidates: []string{},
+			wantMessage:    "no asset matches linux/amd64",
+		},
+	}
+
+	for _, tc := range testcases {
+		_, err := SelectAsset(tc.assets, tc.platform)
+		if err == nil {
+			t.Errorf("want: error, got: nil")
+			continue
+		}
+
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("want: %s, got: %s", tc.wantErr, err)
+		}
+
+		var serr *AssetSelectionError
+		if !errors.As(err, &serr) {
+			t.Errorf("want: AssetSelectionError, got: %#v", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(serr.Candidates, tc.wantCandidates) {
+			t.Errorf("candidates want: %q, got: %q", tc.wantCandidates, serr.Candidates)
+		}
+
+		if err.Error() != tc.wantMessage {
+			t.Errorf("message want: %q, got: %q", tc.wantMessage, err.Error())
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package github
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// recording is HTTP traffic saved by Recorder
+type recording struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// recordedRequest has no header not to save credentials
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// recordedResponse keeps text body as is, and other body, e.g. downloaded asset, in base64
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+func newRecordedResponse(resp *http.Response, header http.Header, body []byte) recordedResponse {
+	r := recordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     header,
+	}
+
+	if utf8.Valid(body) {
+		r.Body = string(body)
+	} else {
+		r.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	return r
+}
+
+func (r recordedResponse) body() ([]byte, error) {
+	if r.BodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(r.BodyBase64)
+	}
+
+	return []byte(r.Body), nil
+}
+
+// response headers which are not saved
+var ignoredResponseHeaders = []string{
+	"Set-Cookie",
+}
+
+// Recorder is http.RoundTripper which saves requests and responses into file
+// The file is rewritten after every request, so that it is complete even if the process is killed
+type Recorder struct {
+	filename  string
+	transport http.RoundTripper
+
+	mu        sync.Mutex
+	recording *recording
+}
+
+// NewRecorder creates new Recorder object sending requests through transport
+// transport can be nil to use http.DefaultTransport
+func NewRecorder(filename string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{
+		filename:  filename,
+		transport: transport,
+		recording: &recording{
+			Interactions: []*interaction{},
+		},
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	for _, h := range ignoredResponseHeaders {
+		header.Del(h)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recording.Interactions = append(r.recording.Interactions, &interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   reqBody,
+		},
+		Response: newRecordedResponse(resp, header, body),
+	})
+
+	b, err := json.MarshalIndent(r.recording, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(r.filename, append(b, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to save recording: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Replayer is http.RoundTripper which returns responses saved by Recorder without network access
+// Requests are matched by method, URL and JSON body. Each response is returned once in recorded order,
+// and then the last one is returned again for the same request
+type Replayer struct {
+	mu        sync.Mutex
+	recording *recording
+	used      []bool
+}
+
+// NewReplayer creates new Replayer object from file saved by Recorder
+func NewReplayer(filename string) (*Replayer, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec recording
+
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", filename, err)
+	}
+
+	return &Replayer{
+		recording: &rec,
+		used:      make([]bool, len(rec.Interactions)),
+	}, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+
+	for i, it := range r.recording.Interactions {
+		if it.Request.Method != req.Method || it.Request.URL != req.URL.String() || it.Request.Body != body {
+			continue
+		}
+
+		if !r.used[i] {
+			r.used[i] = true
+			return newReplayedResponse(req, it)
+		}
+
+		last = i
+	}
+
+	if last >= 0 {
+		return newReplayedResponse(req, r.recording.Interactions[last])
+	}
+
+	return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+}
+
+func newReplayedResponse(req *http.Request, it *interaction) (*http.Response, error) {
+	body, err := it.Response.body()
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded response for %s %s: %w", req.Method, req.URL, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", it.Response.StatusCode, http.StatusText(it.Response.StatusCode)),
+		StatusCode:    it.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        it.Response.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody returns JSON body of the request, and a copy of the request to send instead
+// Other bodies, e.g. uploaded assets, are not saved
+func readRequestBody(req *http.Request) (string, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "", req, nil
+	}
+
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+
+	return string(b), req, nil
+}
@@ -0,0 +1,233 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+	"golang.org/x/oauth2"
+)
+
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie"})
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Ratelimit-Remaining", "4999")
+		fmt.Fprintf(w, `{"method":%q,"body":%q}`, r.Method, body)
+	}))
+	defer ts.Close()
+
+	filename := filepath.Join(t.TempDir(), "recording.json")
+
+	rec := NewRecorder(filename, nil)
+	hc := oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: rec}), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "secret"}))
+
+	send := func(hc *http.Client, method, path, body string) (*http.Response, string, error) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp, string(b), nil
+	}
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+		want   string
+	}{
+		{method: http.MethodGet, path: "/repos/owner/repo", want: `{"method":"GET","body":""}`},
+		{method: http.MethodPost, path: "/repos/owner/repo/releases", body: `{"tag_name":"v1.0.0"}`, want: `{"method":"POST","body":"{\"tag_name\":\"v1.0.0\"}"}`},
+		{method: http.MethodPost, path: "/repos/owner/repo/releases", body: `{"tag_name":"v1.1.0"}`, want: `{"method":"POST","body":"{\"tag_name\":\"v1.1.0\"}"}`},
+	}
+
+	// request body is still sent while recording
+	for _, r := range requests {
+		_, got, err := send(hc, r.method, r.path, r.body)
+		if err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+
+		if got != r.want {
+			t.Errorf("want: %q, got: %q", r.want, got)
+		}
+	}
+
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{"secret", "cookie"} {
+		if bytes.Contains(b, []byte(s)) {
+			t.Errorf("want: %q not recorded, got: %s", s, string(b))
+		}
+	}
+
+	// replayed without server
+	ts.Close()
+
+	rep, err := NewReplayer(filename)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	hc = &http.Client{Transport: rep}
+
+	for _, r := range append(requests, requests[1]) {
+		resp, got, err := send(hc, r.method, r.path, r.body)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if got != r.want {
+			t.Errorf("want: %q, got: %q", r.want, got)
+		}
+
+		if v := resp.Header.Get("X-Ratelimit-Remaining"); v != "4999" {
+			t.Errorf("want: 4999, got: %q", v)
+		}
+	}
+
+	if _, _, err := send(hc, http.MethodDelete, "/repos/owner/repo", ""); err == nil || !strings.Contains(err.Error(), "no recorded response for DELETE "+ts.URL+"/repos/owner/repo") {
+		t.Errorf("want: no recorded response error, got: %v", err)
+	}
+}
+
+func TestRecorder_binaryBody(t *testing.T) {
+	want := []byte{0x1f, 0x8b, 0xff, 0xfe, 0x00, 0x80, 'a'}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(want)
+	}))
+	defer ts.Close()
+
+	filename := filepath.Join(t.TempDir(), "recording.json")
+
+	get := func(transport http.RoundTripper) []byte {
+		resp, err := (&http.Client{Transport: transport}).Get(ts.URL + "/asset")
+		if err != nil {
+			t.Fatalf("want: no error, got: %s", err)
+		}
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return b
+	}
+
+	if got := get(NewRecorder(filename, nil)); !bytes.Equal(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	ts.Close()
+
+	rep, err := NewReplayer(filename)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if got := get(rep); !bytes.Equal(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestRecorder_client(t *testing.T) {
+	ts := httptest.NewServer(newFakeReleasesAPI())
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "recording.json")
+
+	newClient := func(transport http.RoundTripper) *Client {
+		c := NewClientWithTransport(nil, transport)
+		c.api.(*github.Client).BaseURL = u
+
+		return c
+	}
+
+	run := func(c *Client) ([]*Tag, *Tag, error) {
+		tags, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		tag, err := c.DescribeRelease(context.Background(), "owner", "repo", "v1.1.0")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return tags, tag, nil
+	}
+
+	wantTags, wantTag, err := run(newClient(NewRecorder(filename, nil)))
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if wantTag.Release.Commit != "bbbb2222" || len(wantTag.Release.Assets) != 1 {
+		t.Errorf("want: v1.1.0 at bbbb2222 with 1 asset, got: %#v", wantTag.Release)
+	}
+
+	// replayed without server
+	ts.Close()
+
+	rep, err := NewReplayer(filename)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	gotTags, gotTag, err := run(newClient(rep))
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if !reflect.DeepEqual(gotTags, wantTags) {
+		t.Errorf("tags want: %#v, got: %#v", wantTags, gotTags)
+	}
+
+	if !reflect.DeepEqual(gotTag, wantTag) {
+		t.Errorf("release want: %#v, got: %#v", wantTag, gotTag)
+	}
+}
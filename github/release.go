@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// ReleaseParams represents attributes of release to create or edit
+// nil fields are left unchanged on edit
+type ReleaseParams struct {
+	Body       *string
+	Draft      *bool
+	Name       *string
+	Prerelease *bool
+	// Target is commitish the tag is created from if the tag does not exist yet
+	Target *string
+}
+
+func (p *ReleaseParams) repositoryRelease() *github.RepositoryRelease {
+	return &github.RepositoryRelease{
+		Body:            p.Body,
+		Draft:           p.Draft,
+		Name:            p.Name,
+		Prerelease:      p.Prerelease,
+		TargetCommitish: p.Target,
+	}
+}
+
+// CreateRelease creates new release of the given tag
+// The tag is created from params.Target (or default branch) if it does not exist
+func (c *Client) CreateRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error) {
+	if params == nil {
+		params = &ReleaseParams{}
+	}
+
+	r := params.repositoryRelease()
+	r.TagName = github.String(tag)
+
+	release, _, err := c.repositories.CreateRelease(ctx, owner, repo, r)
+	if err != nil {
+		return nil, c.wrapError(ctx, err, owner, repo, tag, ErrRepoNotFound)
+	}
+
+	return toTag(release), nil
+}
+
+// EditRelease updates release of the given tag, including draft release
+func (c *Client) EditRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error) {
+	if params == nil {
+		params = &ReleaseParams{}
+	}
+
+	current, err := c.findRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	release, _, err := c.repositories.EditRelease(ctx, owner, repo, current.GetID(), params.repositoryRelease())
+	if err != nil {
+		return nil, c.wrapError(ctx, err, owner, repo, tag, ErrReleaseNotFound)
+	}
+
+	return toTag(release), nil
+}
+
+// DeleteRelease deletes release of the given tag, including draft release
+// The tag itself is deleted too if deleteTag is true
+func (c *Client) DeleteRelease(ctx context.Context, owner, repo, tag string, deleteTag bool) error {
+	release, err := c.findRelease(ctx, owner, repo, tag)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.repositories.DeleteRelease(ctx, owner, repo, release.GetID()); err != nil {
+		return c.wrapError(ctx, err, owner, repo, tag, ErrReleaseNotFound)
+	}
+
+	if !deleteTag {
+		return nil
+	}
+
+	_, err = c.git.DeleteRef(ctx, owner, repo, "tags/"+tag)
+
+	return c.wrapError(ctx, err, owner, repo, tag, ErrTagNotFound)
+}
+
+// findRelease returns release of the given tag
+// Draft releases are not returned by GetReleaseByTag API, so they are looked up from release list
+func (c *Client) findRelease(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error) {
+	release, err := c.getRelease(ctx, owner, repo, tag)
+	if err == nil {
+		return release, nil
+	}
+
+	if !isNotFound(err) {
+		return nil, c.wrapError(ctx, err, owner, repo, tag, nil)
+	}
+
+	drafts, lerr := c.listReleases(ctx, owner, repo, func(r *github.RepositoryRelease) bool {
+		return r.GetDraft() && r.GetTagName() == tag
+	}, nil, 1)
+	if lerr != nil {
+		return nil, lerr
+	}
+
+	// the repository exists as its releases are listed
+	if len(drafts) == 0 {
+		return nil, &Error{
+			Kind:          ErrReleaseNotFound,
+			Owner:         owner,
+			Repo:          repo,
+			Tag:           tag,
+			Authenticated: c.authenticated,
+			StatusCode:    http.StatusNotFound,
+			Err:           err,
+		}
+	}
+
+	return drafts[0], nil
+}
+
+// toTag converts release API response to Tag
+func toTag(r *github.RepositoryRelease) *Tag {
+	artifactURLs := []string{}
+	assets := []*Asset{}
+
+	for _, asset := range r.Assets {
+		artifactURLs = append(artifactURLs, asset.GetBrowserDownloadURL())
+		assets = append(assets, toAsset(asset))
+	}
+
+	return &Tag{
+		Name: r.GetTagName(),
+		Release: &Release{
+			ArtifactURLs: artifactURLs,
+			Assets:       assets,
+			Author:       r.GetAuthor().GetLogin(),
+			Body:         r.GetBody(),
+			CreatedAt:    r.GetCreatedAt().Time,
+			Draft:        r.GetDraft(),
+			Name:         r.GetName(),
+			Prerelease:   r.GetPrerelease(),
+			PublishedAt:  r.GetPublishedAt().Time,
+			TarballURL:   r.GetTarballURL(),
+			URL:          r.GetHTMLURL(),
+			ZipballURL:   r.GetZipballURL(),
+		},
+	}
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+func (s fakeRepositoriesService) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	return release, &github.Response{}, nil
+}
+
+func (s fakeRepositoriesService) DeleteRelease(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return &github.Response{}, nil
+}
+
+func (s fakeRepositoriesService) EditRelease(ctx context.Context, owner, repo string, id int64, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	return release, &github.Response{}, nil
+}
+
+// newTestClient creates Client which talks to fake GitHub API served by handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	gc := github.NewClient(nil)
+
+	u, err := url.Parse(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc.BaseURL = u
+	gc.UploadURL = u
+
+	return &Client{
+		api:          gc,
+		git:          gc.Git,
+		pullRequests: gc.PullRequests,
+		repositories: gc.Repositories,
+		download:     http.DefaultClient,
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method want: POST, got: %s", r.Method)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[string]interface{}{
+			"tag_name":         "v1.1.0",
+			"target_commitish": "856abeb",
+			"name":             "v1.1.0",
+			"body":             "release notes",
+			"draft":            true,
+			"prerelease":       false,
+		}
+
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("request body want: %#v, got: %#v", want, body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+  "id": 1,
+  "tag_name": "v1.1.0",
+  "name": "v1.1.0",
+  "body": "release notes",
+  "draft": true,
+  "prerelease": false,
+  "created_at": "2021-03-01T00:00:00Z",
+  "html_url": "https://github.com/owner/repo/releases/tag/untagged-1",
+  "author": {"login": "dtan4"}
+}`)
+	})
+
+	c := newTestClient(t, mux)
+
+	got, err := c.CreateRelease(context.Background(), "owner", "repo", "v1.1.0", &ReleaseParams{
+		Body:       github.String("release notes"),
+		Draft:      github.Bool(true),
+		Name:       github.String("v1.1.0"),
+		Prerelease: github.Bool(false),
+		Target:     github.String("856abeb"),
+	})
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := &Tag{
+		Name: "v1.1.0",
+		Release: &Release{
+			ArtifactURLs: []string{},
+			Assets:       []*Asset{},
+			Author:       "dtan4",
+			Body:         "release notes",
+			CreatedAt:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Draft:        true,
+			Name:         "v1.1.0",
+			URL:          "https://github.com/owner/repo/releases/tag/untagged-1",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %#v, got: %#v", want.Release, got.Release)
+	}
+}
+
+func TestEditRelease(t *testing.T) {
+	testcases := []struct {
+		tag    string
+		params *ReleaseParams
+		wantID string
+		body   map[string]interface{}
+	}{
+		{
+			tag: "v1.0.0",
+			params: &ReleaseParams{
+				Prerelease: github.Bool(false),
+			},
+			wantID: "10",
+			body: map[string]interface{}{
+				"prerelease": false,
+			},
+		},
+		{
+			// draft release is not found by tag
+			tag: "v1.1.0",
+			params: &ReleaseParams{
+				Draft: github.Bool(false),
+				Body:  github.String("updated"),
+			},
+			wantID: "11",
+			body: map[string]interface{}{
+				"draft": false,
+				"body":  "updated",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		var edited string
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/releases/tags/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/repos/owner/repo/releases/tags/v1.0.0" {
+				http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+				return
+			}
+
+			fmt.Fprint(w, `{"id": 10, "tag_name": "v1.0.0"}`)
+		})
+		mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `[{"id": 11, "tag_name": "v1.1.0", "draft": true}, {"id": 10, "tag_name": "v1.0.0"}]`)
+		})
+		mux.HandleFunc("/repos/owner/repo/releases/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch {
+				t.Errorf("method want: PATCH, got: %s", r.Method)
+			}
+
+			edited = r.URL.Path[len("/repos/owner/repo/releases/"):]
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(body, tc.body) {
+				t.Errorf("request body want: %#v, got: %#v", tc.body, body)
+			}
+
+			fmt.Fprintf(w, `{"id": %s, "tag_name": %q}`, edited, tc.tag)
+		})
+
+		c := newTestClient(t, mux)
+
+		got, err := c.EditRelease(context.Background(), "owner", "repo", tc.tag, tc.params)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if edited != tc.wantID {
+			t.Errorf("edited release want: %s, got: %s", tc.wantID, edited)
+		}
+
+		if got.Name != tc.tag {
+			t.Errorf("tag want: %s, got: %s", tc.tag, got.Name)
+		}
+	}
+}
+
+func TestEditRelease_notFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/releases/tags/v9.9.9", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/owner/repo/releases", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	c := newTestClient(t, mux)
+
+	_, err := c.EditRelease(context.Background(), "owner", "repo", "v9.9.9", &ReleaseParams{})
+	if !isNotFound(err) {
+		t.Errorf("want: not found error, got: %#v", err)
+	}
+}
+
+func TestDeleteRelease(t *testing.T) {
+	testcases := []struct {
+		deleteTag bool
+		want      []string
+	}{
+		{
+			deleteTag: false,
+			want: []string{
+				"DELETE /repos/owner/repo/releases/10",
+			},
+		},
+		{
+			deleteTag: true,
+			want: []string{
+				"DELETE /repos/owner/repo/releases/10",
+				"DELETE /repos/owner/repo/git/refs/tags/v1.0.0",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		calls := []string{}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/releases/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"id": 10, "tag_name": "v1.0.0"}`)
+		})
+		mux.HandleFunc("/repos/owner/repo/releases/10", func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		mux.HandleFunc("/repos/owner/repo/git/refs/tags/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		c := newTestClient(t, mux)
+
+		if err := c.DeleteRelease(context.Background(), "owner", "repo", "v1.0.0", tc.deleteTag); err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if !reflect.DeepEqual(calls, tc.want) {
+			t.Errorf("calls want: %q, got: %q", tc.want, calls)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// Repository represents GitHub repository
+type Repository struct {
+	Archived bool
+	Fork     bool
+	Name     string
+	Owner    string
+	Private  bool
+	Topics   []string
+}
+
+// RepositoryFilter represents conditions to narrow down repositories
+type RepositoryFilter struct {
+	// IncludeArchived lists archived repositories too
+	IncludeArchived bool
+	// IncludeForks lists forked repositories too
+	IncludeForks bool
+	// Visibility is one of "public", "private" or empty (both)
+	Visibility string
+	// Topics lists only repositories which have all of the topics
+	Topics []string
+}
+
+func (f *RepositoryFilter) match(r *Repository) bool {
+	if r.Archived && !f.IncludeArchived {
+		return false
+	}
+
+	if r.Fork && !f.IncludeForks {
+		return false
+	}
+
+	switch f.Visibility {
+	case "public":
+		if r.Private {
+			return false
+		}
+	case "private":
+		if !r.Private {
+			return false
+		}
+	}
+
+	for _, topic := range f.Topics {
+		found := false
+
+		for _, t := range r.Topics {
+			if t == topic {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListRepositories retrieves repositories owned by the given organization or user
+// filter can be nil to retrieve all of them
+func (c *Client) ListRepositories(ctx context.Context, owner string, filter *RepositoryFilter) ([]*Repository, error) {
+	if filter == nil {
+		filter = &RepositoryFilter{
+			IncludeArchived: true,
+			IncludeForks:    true,
+		}
+	}
+
+	repos, err := c.listOrgRepositories(ctx, owner)
+	if err != nil {
+		if !isNotFound(err) {
+			return []*Repository{}, c.wrapError(ctx, err, owner, "", "", nil)
+		}
+
+		// not an organization, try as user
+		repos, err = c.listUserRepositories(ctx, owner)
+		if err != nil {
+			return []*Repository{}, c.wrapError(ctx, err, owner, "", "", ErrRepoNotFound)
+		}
+	}
+
+	rs := []*Repository{}
+
+	for _, r := range repos {
+		repo := &Repository{
+			Archived: r.GetArchived(),
+			Fork:     r.GetFork(),
+			Name:     r.GetName(),
+			Owner:    r.GetOwner().GetLogin(),
+			Private:  r.GetPrivate(),
+			Topics:   r.Topics,
+		}
+
+		if !filter.match(repo) {
+			continue
+		}
+
+		rs = append(rs, repo)
+	}
+
+	return rs, nil
+}
+
+// LatestRelease returns the latest published release of the given repository
+// nil is returned if the repository has no release
+func (c *Client) LatestRelease(ctx context.Context, owner, repo string) (*Tag, error) {
+	release, _, err := c.repositories.GetLatestRelease(ctx, owner, repo)
+	if err != nil {
+		if isNotFound(err) && c.repositoryExists(ctx, owner, repo) {
+			return nil, nil
+		}
+
+		return nil, c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
+	}
+
+	tag := newReleaseTag(release.GetTagName(), release)
+	tag.Release.PublishedAt = release.GetPublishedAt().Time
+	tag.Release.URL = release.GetHTMLURL()
+
+	return tag, nil
+}
+
+func (c *Client) listOrgRepositories(ctx context.Context, org string) ([]*github.Repository, error) {
+	allRepos := []*github.Repository{}
+
+	listOpts := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+		},
+	}
+
+	for {
+		repos, resp, err := c.repositories.ListByOrg(ctx, org, listOpts)
+		if err != nil {
+			return []*github.Repository{}, err
+		}
+
+		allRepos = append(allRepos, repos...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return allRepos, nil
+}
+
+func (c *Client) listUserRepositories(ctx context.Context, user string) ([]*github.Repository, error) {
+	allRepos := []*github.Repository{}
+
+	listOpts := &github.RepositoryListOptions{
+		Type: "owner",
+		ListOptions: github.ListOptions{
+			PerPage: perPage,
+		},
+	}
+
+	// /users/:user/repos returns only public repositories,
+	// so repositories of the authenticated user are listed via /user/repos to include private ones
+	listUser := user
+	if c.isAuthenticatedUser(ctx, user) {
+		listUser = ""
+	}
+
+	for {
+		repos, resp, err := c.repositories.List(ctx, listUser, listOpts)
+		if err != nil {
+			return []*github.Repository{}, err
+		}
+
+		allRepos = append(allRepos, repos...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return allRepos, nil
+}
+
+// isAuthenticatedUser reports whether the given user is the owner of credentials
+// false is returned if it cannot be determined, e.g. with GitHub App installation token
+func (c *Client) isAuthenticatedUser(ctx context.Context, user string) bool {
+	if !c.authenticated || c.users == nil {
+		return false
+	}
+
+	u, _, err := c.users.Get(ctx, "")
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.GetLogin(), user)
+}
+
+func isNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,245 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+)
+
+func notFoundError() error {
+	return &github.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: http.StatusNotFound,
+			Request:    &http.Request{Method: http.MethodGet},
+		},
+		Message: "Not Found",
+	}
+}
+
+func (s fakeRepositoriesService) GetLatestRelease(ctx context.Context, owner, repo string) (*github.RepositoryRelease, *github.Response, error) {
+	if repo == "norelease" {
+		return nil, nil, notFoundError()
+	}
+
+	return s.GetReleaseByTag(ctx, owner, repo, "v1")
+}
+
+func (s fakeRepositoriesService) ListByOrg(ctx context.Context, org string, opts *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	if org != "org" {
+		return nil, nil, notFoundError()
+	}
+
+	if opts.Page == 0 {
+		return []*github.Repository{
+			&github.Repository{
+				Name:   github.String("api"),
+				Owner:  &github.User{Login: github.String("org")},
+				Topics: []string{"backend"},
+			},
+		}, &github.Response{NextPage: 2}, nil
+	}
+
+	return []*github.Repository{
+		&github.Repository{
+			Name:     github.String("legacy"),
+			Owner:    &github.User{Login: github.String("org")},
+			Archived: github.Bool(true),
+			Private:  github.Bool(true),
+		},
+	}, &github.Response{}, nil
+}
+
+func (s fakeRepositoriesService) List(ctx context.Context, user string, opts *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error) {
+	if user == "" {
+		return []*github.Repository{
+			&github.Repository{
+				Name:    github.String("secret"),
+				Owner:   &github.User{Login: github.String("dtan4")},
+				Private: github.Bool(true),
+			},
+		}, &github.Response{}, nil
+	}
+
+	return []*github.Repository{
+		&github.Repository{
+			Name:  github.String("dotfiles"),
+			Owner: &github.User{Login: github.String(user)},
+			Fork:  github.Bool(true),
+		},
+	}, &github.Response{}, nil
+}
+
+func TestListRepositories(t *testing.T) {
+	c := &Client{
+		repositories: fakeRepositoriesService{},
+	}
+
+	testcases := []struct {
+		owner  string
+		filter *RepositoryFilter
+		want   []*Repository
+	}{
+		{
+			owner: "org",
+			want: []*Repository{
+				&Repository{
+					Name:   "api",
+					Owner:  "org",
+					Topics: []string{"backend"},
+				},
+				&Repository{
+					Name:     "legacy",
+					Owner:    "org",
+					Archived: true,
+					Private:  true,
+				},
+			},
+		},
+		{
+			owner:  "org",
+			filter: &RepositoryFilter{},
+			want: []*Repository{
+				&Repository{
+					Name:   "api",
+					Owner:  "org",
+					Topics: []string{"backend"},
+				},
+			},
+		},
+		{
+			owner: "org",
+			filter: &RepositoryFilter{
+				IncludeArchived: true,
+				Visibility:      "private",
+			},
+			want: []*Repository{
+				&Repository{
+					Name:     "legacy",
+					Owner:    "org",
+					Archived: true,
+					Private:  true,
+				},
+			},
+		},
+		{
+			owner: "org",
+			filter: &RepositoryFilter{
+				IncludeArchived: true,
+				Topics:          []string{"frontend"},
+			},
+			want: []*Repository{},
+		},
+		{
+			owner: "dtan4",
+			want: []*Repository{
+				&Repository{
+					Name:  "dotfiles",
+					Owner: "dtan4",
+					Fork:  true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := c.ListRepositories(context.Background(), tc.owner, tc.filter)
+		if err != nil {
+			t.Errorf("want no error, got: %#v", err)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("want: %#v, got: %#v", tc.want, got)
+		}
+	}
+}
+
+type fakeUsersService struct {
+	Login string
+}
+
+func (s fakeUsersService) Get(ctx context.Context, user string) (*github.User, *github.Response, error) {
+	return &github.User{Login: github.String(s.Login)}, &github.Response{}, nil
+}
+
+func TestListRepositories_authenticatedUser(t *testing.T) {
+	c := &Client{
+		repositories:  fakeRepositoriesService{},
+		users:         fakeUsersService{Login: "dtan4"},
+		authenticated: true,
+	}
+
+	testcases := []struct {
+		owner string
+		want  []*Repository
+	}{
+		{
+			owner: "dtan4",
+			want: []*Repository{
+				&Repository{
+					Name:    "secret",
+					Owner:   "dtan4",
+					Private: true,
+				},
+			},
+		},
+		{
+			owner: "octocat",
+			want: []*Repository{
+				&Repository{
+					Name:  "dotfiles",
+					Owner: "octocat",
+					Fork:  true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := c.ListRepositories(context.Background(), tc.owner, nil)
+		if err != nil {
+			t.Errorf("want no error, got: %#v", err)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("want: %#v, got: %#v", tc.want, got)
+		}
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	c := &Client{
+		repositories: fakeRepositoriesService{},
+	}
+
+	want := &Tag{
+		Name: "v1",
+		Release: &Release{
+			CreatedAt:   time.Date(2018, 12, 13, 0, 30, 24, 0, time.UTC),
+			Name:        "v1",
+			PublishedAt: time.Date(2018, 12, 14, 0, 30, 24, 0, time.UTC),
+			URL:         "https://github.com/owner/repo/releases/tag/v1",
+		},
+	}
+
+	got, err := c.LatestRelease(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Errorf("want no error, got: %#v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %#v, got: %#v", want, got)
+	}
+
+	got, err = c.LatestRelease(context.Background(), "owner", "norelease")
+	if err != nil {
+		t.Errorf("want no error, got: %#v", err)
+	}
+
+	if got != nil {
+		t.Errorf("want: nil, got: %#v", got)
+	}
+}
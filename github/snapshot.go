@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrOffline is returned by SnapshotClient for operations which need GitHub API
+var ErrOffline = errors.New("not available in offline mode")
+
+// Snapshot represents tags and releases of a repository saved for offline use
+type Snapshot struct {
+	Owner    string    `json:"owner"`
+	Repo     string    `json:"repo"`
+	SyncedAt time.Time `json:"synced_at"`
+	// Tags are in the order returned by GitHub API, Release of them is not set
+	Tags []*Tag `json:"tags"`
+	// Releases are newest first, including drafts
+	Releases []*Tag `json:"releases"`
+}
+
+// TakeSnapshot retrieves all tags and releases, including release notes and assets, of the given repository
+func TakeSnapshot(ctx context.Context, client ClientInterface, owner, repo string) (*Snapshot, error) {
+	s := &Snapshot{
+		Owner:    owner,
+		Repo:     repo,
+		Tags:     []*Tag{},
+		Releases: []*Tag{},
+	}
+
+	if err := client.WalkTags(ctx, owner, repo, func(t *Tag) error {
+		s.Tags = append(s.Tags, t)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := client.WalkReleases(ctx, owner, repo, func(t *Tag) error {
+		s.Releases = append(s.Releases, t)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SnapshotClient implements ClientInterface serving tags and releases from snapshots
+// Operations which need GitHub API return ErrOffline
+type SnapshotClient struct {
+	load func(owner, repo string) (*Snapshot, error)
+}
+
+// NewSnapshotClient creates new SnapshotClient object
+// load returns snapshot of the given repository, it is called on every request
+func NewSnapshotClient(load func(owner, repo string) (*Snapshot, error)) *SnapshotClient {
+	return &SnapshotClient{
+		load: load,
+	}
+}
+
+// DescribeRelease returns detail of the given published release
+func (c *SnapshotClient) DescribeRelease(ctx context.Context, owner, repo, tag string) (*Tag, error) {
+	s, err := c.load(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range s.Releases {
+		if r.Name != tag || r.Release.Draft {
+			continue
+		}
+
+		release := *r.Release
+		release.Commit = s.tagCommit(tag)
+
+		return &Tag{
+			Name:    r.Name,
+			Release: &release,
+		}, nil
+	}
+
+	// no API response is involved, so Err is the kind itself
+	return nil, &Error{
+		Kind:  ErrReleaseNotFound,
+		Owner: owner,
+		Repo:  repo,
+		Tag:   tag,
+		Err:   ErrReleaseNotFound,
+	}
+}
+
+// LatestRelease returns the newest published release which is not prerelease
+// nil is returned if the repository has no release
+func (c *SnapshotClient) LatestRelease(ctx context.Context, owner, repo string) (*Tag, error) {
+	s, err := c.load(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range s.Releases {
+		if !r.Release.Draft && !r.Release.Prerelease {
+			return r, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ListTagsAndReleases returns tags and releases of the given repository in the same order as Client does
+// filter can be nil to return all of them
+func (c *SnapshotClient) ListTagsAndReleases(ctx context.Context, owner, repo string, filter *ListFilter) ([]*Tag, error) {
+	if filter == nil {
+		filter = &ListFilter{}
+	}
+
+	s, err := c.load(owner, repo)
+	if err != nil {
+		return []*Tag{}, err
+	}
+
+	ts := []*Tag{}
+
+	if filter.releasesOnly() {
+		for _, r := range s.Releases {
+			if !filter.Since.IsZero() && r.Release.CreatedAt.Before(filter.Since) {
+				break
+			}
+
+			if r.Release.Draft || !filter.matchName(r.Name) || !filter.matchTime(r.Release.CreatedAt) {
+				continue
+			}
+
+			ts = append(ts, r)
+
+			if filter.Limit > 0 && len(ts) >= filter.Limit {
+				break
+			}
+		}
+
+		return ts, nil
+	}
+
+	releasesMap := map[string]*Release{}
+
+	for _, r := range s.Releases {
+		releasesMap[r.Name] = r.Release
+	}
+
+	for _, t := range s.Tags {
+		r, ok := releasesMap[t.Name]
+		if filter.Type == TagTypeTag && ok {
+			continue
+		}
+
+		if !filter.matchName(t.Name) {
+			continue
+		}
+
+		ts = append(ts, &Tag{
+			Commit:  t.Commit,
+			Name:    t.Name,
+			Release: r,
+		})
+
+		if filter.Limit > 0 && len(ts) >= filter.Limit {
+			break
+		}
+	}
+
+	return ts, nil
+}
+
+// WalkReleases calls fn for each release of the given repository, newest first, including drafts
+func (c *SnapshotClient) WalkReleases(ctx context.Context, owner, repo string, fn func(*Tag) error) error {
+	s, err := c.load(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range s.Releases {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WalkTags calls fn for each tag of the given repository
+// Release of the tag is not set
+func (c *SnapshotClient) WalkTags(ctx context.Context, owner, repo string, fn func(*Tag) error) error {
+	s, err := c.load(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range s.Tags {
+		if err := fn(&Tag{Commit: t.Commit, Name: t.Name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *SnapshotClient) CreateRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) DeleteRelease(ctx context.Context, owner, repo, tag string, deleteTag bool) error {
+	return ErrOffline
+}
+
+func (c *SnapshotClient) DeleteReleaseAsset(ctx context.Context, owner, repo, tag, name string) error {
+	return ErrOffline
+}
+
+func (c *SnapshotClient) EditRelease(ctx context.Context, owner, repo, tag string, params *ReleaseParams) (*Tag, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) GenerateReleaseNotes(ctx context.Context, owner, repo, tag, previousTag string) (string, error) {
+	return "", ErrOffline
+}
+
+func (c *SnapshotClient) ListCommits(ctx context.Context, owner, repo, from, to string) ([]*Commit, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) ListMergedPullRequests(ctx context.Context, owner, repo, from, to string) ([]*PullRequest, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) ListRepositories(ctx context.Context, owner string, filter *RepositoryFilter) ([]*Repository, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) OpenReleaseAsset(ctx context.Context, owner, repo string, asset *Asset, offset, length int64) (io.ReadCloser, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) OpenSourceArchive(ctx context.Context, owner, repo, tag string, format ArchiveFormat) (io.ReadCloser, error) {
+	return nil, ErrOffline
+}
+
+func (c *SnapshotClient) UploadReleaseAsset(ctx context.Context, owner, repo, tag, filename string, clobber bool) (*Asset, error) {
+	return nil, ErrOffline
+}
+
+// tagCommit returns SHA of the commit the tag points to, or empty string
+func (s *Snapshot) tagCommit(name string) string {
+	for _, t := range s.Tags {
+		if t.Name == name {
+			return t.Commit
+		}
+	}
+
+	return ""
+}
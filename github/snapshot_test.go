@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSnapshotClient(s *Snapshot) *SnapshotClient {
+	return NewSnapshotClient(func(owner, repo string) (*Snapshot, error) {
+		if owner != s.Owner || repo != s.Repo {
+			return nil, errors.New("not synced")
+		}
+
+		return s, nil
+	})
+}
+
+func TestSnapshotClient_ListTagsAndReleases(t *testing.T) {
+	c := &Client{
+		repositories: newPagedRepositoriesService(),
+	}
+
+	s, err := TakeSnapshot(context.Background(), c, "owner", "repo")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if len(s.Tags) != 6 || len(s.Releases) != 5 {
+		t.Errorf("want: 6 tags and 5 releases, got: %d tags and %d releases", len(s.Tags), len(s.Releases))
+	}
+
+	sc := newTestSnapshotClient(s)
+
+	filters := []*ListFilter{
+		nil,
+		{Limit: 3},
+		{Type: TagTypeTag},
+		{Match: func(name string) bool { return strings.HasPrefix(name, "v1.2") }},
+		{Type: TagTypeRelease},
+		{Type: TagTypeRelease, Limit: 1},
+		{Since: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{Since: time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC), Until: time.Date(2020, 1, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	// snapshot must list the same tags as GitHub API
+	for i, filter := range filters {
+		want, err := c.ListTagsAndReleases(context.Background(), "owner", "repo", filter)
+		if err != nil {
+			t.Fatalf("#%d want: no error, got: %s", i, err)
+		}
+
+		got, err := sc.ListTagsAndReleases(context.Background(), "owner", "repo", filter)
+		if err != nil {
+			t.Errorf("#%d want: no error, got: %s", i, err)
+			continue
+		}
+
+		if len(got) != len(want) {
+			t.Errorf("#%d want: %d tags, got: %d tags", i, len(want), len(got))
+			continue
+		}
+
+		for j := range want {
+			if got[j].Name != want[j].Name || (got[j].Release == nil) != (want[j].Release == nil) {
+				t.Errorf("#%d want: %s (release: %t), got: %s (release: %t)", i, want[j].Name, want[j].Release != nil, got[j].Name, got[j].Release != nil)
+			}
+		}
+	}
+
+	if _, err := sc.ListTagsAndReleases(context.Background(), "owner", "other", nil); err == nil || err.Error() != "not synced" {
+		t.Errorf("want: not synced, got: %v", err)
+	}
+}
+
+func TestSnapshotClient_DescribeRelease(t *testing.T) {
+	release := func(tag string, draft, prerelease bool) *Tag {
+		return &Tag{
+			Name: tag,
+			Release: &Release{
+				Body:       "release " + tag,
+				Draft:      draft,
+				Name:       tag,
+				Prerelease: prerelease,
+			},
+		}
+	}
+
+	sc := newTestSnapshotClient(&Snapshot{
+		Owner: "owner",
+		Repo:  "repo",
+		Tags: []*Tag{
+			{Name: "v1.1.0-rc.1", Commit: "ccc"},
+			{Name: "v1.0.0", Commit: "aaa"},
+		},
+		Releases: []*Tag{
+			release("v1.2.0", true, false),
+			release("v1.1.0-rc.1", false, true),
+			release("v1.0.0", false, false),
+		},
+	})
+
+	got, err := sc.DescribeRelease(context.Background(), "owner", "repo", "v1.0.0")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	want := &Tag{
+		Name: "v1.0.0",
+		Release: &Release{
+			Body:   "release v1.0.0",
+			Commit: "aaa",
+			Name:   "v1.0.0",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %#v, got: %#v", want.Release, got.Release)
+	}
+
+	// draft releases are not returned as GitHub API does
+	for _, tag := range []string{"v1.2.0", "v0.9.0"} {
+		_, err := sc.DescribeRelease(context.Background(), "owner", "repo", tag)
+		if err == nil {
+			t.Errorf("%s: want: error, got: nil", tag)
+			continue
+		}
+
+		if !errors.Is(err, ErrReleaseNotFound) {
+			t.Errorf("%s: want: %s, got: %s", tag, ErrReleaseNotFound, err)
+		}
+	}
+
+	latest, err := sc.LatestRelease(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	if latest == nil || latest.Name != "v1.0.0" {
+		t.Errorf("want: v1.0.0, got: %#v", latest)
+	}
+
+	if _, err := sc.CreateRelease(context.Background(), "owner", "repo", "v1.3.0", &ReleaseParams{}); err != ErrOffline {
+		t.Errorf("want: %s, got: %v", ErrOffline, err)
+	}
+}
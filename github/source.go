@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// ArchiveFormat represents format of source code archive
+type ArchiveFormat string
+
+const (
+	ArchiveFormatTar ArchiveFormat = "tar"
+	ArchiveFormatZip ArchiveFormat = "zip"
+)
+
+// SourceArchiveURL returns short-lived URL to download source code archive of the given tag, which needs no release
+// The URL is accessible without credential even for private repository
+func (c *Client) SourceArchiveURL(ctx context.Context, owner, repo, tag string, format ArchiveFormat) (string, error) {
+	var af github.ArchiveFormat
+
+	switch format {
+	case ArchiveFormatTar:
+		af = github.Tarball
+	case ArchiveFormatZip:
+		af = github.Zipball
+	default:
+		return "", fmt.Errorf("unknown archive format: %s", format)
+	}
+
+	u, resp, err := c.repositories.GetArchiveLink(ctx, owner, repo, af, &github.RepositoryContentGetOptions{Ref: tag}, true)
+	if err != nil {
+		// archive API reports error by status code only
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = &github.ErrorResponse{Response: resp.Response, Message: err.Error()}
+		}
+
+		return "", c.wrapError(ctx, err, owner, repo, tag, ErrTagNotFound)
+	}
+
+	return u.String(), nil
+}
+
+// OpenSourceArchive returns reader of source code archive of the given tag
+// The archive is downloaded through the client transport, so that it is recorded and replayed as API traffic
+func (c *Client) OpenSourceArchive(ctx context.Context, owner, repo, tag string, format ArchiveFormat) (io.ReadCloser, error) {
+	u, err := c.SourceArchiveURL(ctx, owner, repo, tag, format)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.download.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download source archive of %s/%s@%s: %s", owner, repo, tag, resp.Status)
+	}
+
+	return resp.Body, nil
+}
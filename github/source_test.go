@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v33/github"
+)
+
+func (s fakeRepositoriesService) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error) {
+	return &url.URL{}, &github.Response{}, nil
+}
+
+func TestSourceArchiveURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/tarball/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://codeload.github.com/owner/repo/legacy.tar.gz/refs/tags/v1.0.0", http.StatusFound)
+	})
+	mux.HandleFunc("/repos/owner/repo/zipball/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://codeload.github.com/owner/repo/legacy.zip/refs/tags/v1.0.0", http.StatusFound)
+	})
+
+	c := newTestClient(t, mux)
+
+	testcases := []struct {
+		format ArchiveFormat
+		want   string
+	}{
+		{format: ArchiveFormatTar, want: "https://codeload.github.com/owner/repo/legacy.tar.gz/refs/tags/v1.0.0"},
+		{format: ArchiveFormatZip, want: "https://codeload.github.com/owner/repo/legacy.zip/refs/tags/v1.0.0"},
+	}
+
+	for _, tc := range testcases {
+		got, err := c.SourceArchiveURL(context.Background(), "owner", "repo", "v1.0.0", tc.format)
+		if err != nil {
+			t.Errorf("want: no error, got: %s", err)
+			continue
+		}
+
+		if got != tc.want {
+			t.Errorf("want: %s, got: %s", tc.want, got)
+		}
+	}
+
+	if _, err := c.SourceArchiveURL(context.Background(), "owner", "repo", "v9.9.9", ArchiveFormatTar); err == nil {
+		t.Errorf("want: error for unknown tag, got: nil")
+	}
+}
+
+func TestOpenSourceArchive(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/tarball/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+r.Host+"/codeload/legacy.tar.gz", http.StatusFound)
+	})
+	mux.HandleFunc("/codeload/legacy.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "tarball")
+	})
+	mux.HandleFunc("/repos/owner/repo/zipball/v1.0.0", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+r.Host+"/codeload/expired.zip", http.StatusFound)
+	})
+	mux.HandleFunc("/codeload/expired.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	c := newTestClient(t, mux)
+
+	rc, err := c.OpenSourceArchive(context.Background(), "owner", "repo", "v1.0.0", ArchiveFormatTar)
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != "tarball" {
+		t.Errorf("want: %q, got: %q", "tarball", string(b))
+	}
+
+	_, err = c.OpenSourceArchive(context.Background(), "owner", "repo", "v1.0.0", ArchiveFormatZip)
+	if want := "failed to download source archive of owner/repo@v1.0.0: 403 Forbidden"; err == nil || err.Error() != want {
+		t.Errorf("want: %q, got: %v", want, err)
+	}
+}
@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v33/github"
+)
+
+// WalkReleases calls fn for each release of the given repository, newest first, including drafts
+// Releases are fetched page by page, so that fn can process large repository without holding all of them
+func (c *Client) WalkReleases(ctx context.Context, owner, repo string, fn func(*Tag) error) error {
+	listOpts := &github.ListOptions{
+		PerPage: perPage,
+	}
+
+	for {
+		releases, resp, err := c.repositories.ListReleases(ctx, owner, repo, listOpts)
+		if err != nil {
+			return c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
+		}
+
+		for _, r := range releases {
+			if err := fn(toTag(r)); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return nil
+}
+
+// WalkTags calls fn for each tag of the given repository
+// Release of the tag is not set
+func (c *Client) WalkTags(ctx context.Context, owner, repo string, fn func(*Tag) error) error {
+	listOpts := &github.ListOptions{
+		PerPage: perPage,
+	}
+
+	for {
+		tags, resp, err := c.repositories.ListTags(ctx, owner, repo, listOpts)
+		if err != nil {
+			return c.wrapError(ctx, err, owner, repo, "", ErrRepoNotFound)
+		}
+
+		for _, t := range tags {
+			if err := fn(&Tag{Commit: t.GetCommit().GetSHA(), Name: t.GetName()}); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return nil
+}
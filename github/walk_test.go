@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWalkReleases(t *testing.T) {
+	s := newPagedRepositoriesService()
+	c := &Client{
+		repositories: s,
+	}
+
+	got := []string{}
+
+	err := c.WalkReleases(context.Background(), "owner", "repo", func(tag *Tag) error {
+		got = append(got, tag.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("want: no error, got: %s", err)
+	}
+
+	// drafts are included
+	want := []string{"v1.4.0", "v1.3.0", "v1.2.0", "v1.1.0", "v1.0.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	if wantPages := []int{0, 2, 3}; !reflect.DeepEqual(*s.releasePages, wantPages) {
+		t.Errorf("pages want: %v, got: %v", wantPages, *s.releasePages)
+	}
+}
+
+func TestWalkTags_stop(t *testing.T) {
+	s := newPagedRepositoriesService()
+	c := &Client{
+		repositories: s,
+	}
+
+	stop := errors.New("stop")
+	got := []string{}
+
+	err := c.WalkTags(context.Background(), "owner", "repo", func(tag *Tag) error {
+		got = append(got, tag.Name)
+
+		if tag.Name == "v1.2.0" {
+			return stop
+		}
+
+		return nil
+	})
+	if err != stop {
+		t.Errorf("want: %s, got: %v", stop, err)
+	}
+
+	want := []string{"v1.3.0", "v1.2.1", "v1.2.0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %q, got: %q", want, got)
+	}
+
+	// pagination stops once fn returns error
+	if wantPages := []int{0, 2}; !reflect.DeepEqual(*s.tagPages, wantPages) {
+		t.Errorf("pages want: %v, got: %v", wantPages, *s.tagPages)
+	}
+}
@@ -5,5 +5,9 @@ go 1.16
 require (
 	github.com/google/go-github/v33 v33.0.0
 	github.com/spf13/cobra v1.1.3
+	github.com/spf13/pflag v1.0.5
+	github.com/ulikunitz/xz v0.5.10
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
+	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
+	modernc.org/sqlite v1.10.6
 )
@@ -0,0 +1,322 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	styleReset     = "\x1b[0m"
+	styleBold      = "\x1b[1m"
+	styleDim       = "\x1b[2m"
+	styleItalic    = "\x1b[3m"
+	styleUnderline = "\x1b[4m"
+	styleCode      = "\x1b[36m"
+	styleLink      = "\x1b[34;4m"
+
+	ruleWidth = 40
+)
+
+var (
+	fenceRe       = regexp.MustCompile("^\\s*(```+|~~~+)")
+	headingRe     = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleRe        = regexp.MustCompile(`^\s{0,3}((-\s*){3,}|(\*\s*){3,}|(_\s*){3,})$`)
+	listRe        = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	taskRe        = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	quoteRe       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	tableSepRe    = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	imageRe       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	linkRe        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	autolinkRe    = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	boldRe        = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRe      = regexp.MustCompile(`\*([^*\s][^*]*)\*|(^|[^\w])_([^_\s][^_]*)_([^\w]|$)`)
+	strikeRe      = regexp.MustCompile(`~~([^~]+)~~`)
+	ansiRe        = regexp.MustCompile("\x1b\\[[0-9;]*m|\x1b\\]8;;[^\x1b]*\x1b\\\\")
+	codeSpanRe    = regexp.MustCompile("(`+)(.+?)(`+)")
+	escapableRe   = regexp.MustCompile(`\\([\\` + "`" + `*_{}\[\]()#+\-.!|~>])`)
+	placeholderRe = regexp.MustCompile("\x00[0-9]+\x00")
+)
+
+// Renderer renders Markdown text for terminal
+type Renderer struct {
+	// Color enables ANSI text styles
+	Color bool
+	// Hyperlinks enables OSC 8 hyperlinks instead of printing URLs after link text
+	Hyperlinks bool
+}
+
+// Render converts Markdown text to human-readable text for terminal
+func (r *Renderer) Render(src string) string {
+	// NUL is reserved for placeholders
+	src = strings.ReplaceAll(src, "\x00", "")
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	out := []string{}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := fenceRe.FindStringSubmatch(line); m != nil {
+			fence := m[1]
+			i++
+
+			for ; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+
+				out = append(out, "    "+r.style(styleCode, lines[i]))
+			}
+
+			continue
+		}
+
+		if isTableRow(line) && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "|") {
+			rows := [][]string{splitTableRow(line)}
+			i += 2
+
+			for ; i < len(lines) && isTableRow(lines[i]); i++ {
+				rows = append(rows, splitTableRow(lines[i]))
+			}
+			i--
+
+			out = append(out, r.renderTable(rows)...)
+
+			continue
+		}
+
+		out = append(out, r.renderLine(line))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func (r *Renderer) renderLine(line string) string {
+	if m := headingRe.FindStringSubmatch(line); m != nil {
+		text := r.inline(m[2])
+
+		if len(m[1]) <= 2 {
+			return r.style(styleBold+styleUnderline, text)
+		}
+
+		return r.style(styleBold, text)
+	}
+
+	if ruleRe.MatchString(line) {
+		return r.style(styleDim, strings.Repeat("─", ruleWidth))
+	}
+
+	if m := quoteRe.FindStringSubmatch(line); m != nil {
+		return r.style(styleDim, "│ ") + r.style(styleItalic, r.inline(m[1]))
+	}
+
+	if m := listRe.FindStringSubmatch(line); m != nil {
+		indent, marker, text := m[1], m[2], m[3]
+
+		if !strings.ContainsAny(marker, ".)") {
+			marker = "•"
+
+			if t := taskRe.FindStringSubmatch(text); t != nil {
+				if t[1] == " " {
+					marker = "☐"
+				} else {
+					marker = "☑"
+				}
+
+				text = t[2]
+			}
+		}
+
+		return indent + marker + " " + r.inline(text)
+	}
+
+	return r.inline(line)
+}
+
+func (r *Renderer) renderTable(rows [][]string) []string {
+	cols := 0
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	cells := make([][]string, len(rows))
+	widths := make([]int, cols)
+
+	for i, row := range rows {
+		cells[i] = make([]string, cols)
+
+		for j := 0; j < cols; j++ {
+			if j < len(row) {
+				cells[i][j] = r.inline(row[j])
+			}
+
+			if w := displayWidth(cells[i][j]); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	lines := []string{}
+
+	for i, row := range cells {
+		ss := []string{}
+
+		for j, cell := range row {
+			padded := cell + strings.Repeat(" ", widths[j]-displayWidth(cell))
+
+			if i == 0 {
+				padded = r.style(styleBold, padded)
+			}
+
+			ss = append(ss, padded)
+		}
+
+		lines = append(lines, strings.TrimRight(strings.Join(ss, " │ "), " "))
+
+		if i == 0 {
+			seps := []string{}
+
+			for _, w := range widths {
+				seps = append(seps, strings.Repeat("─", w))
+			}
+
+			lines = append(lines, strings.Join(seps, "─┼─"))
+		}
+	}
+
+	return lines
+}
+
+// inline renders inline elements, leaving code spans untouched
+func (r *Renderer) inline(text string) string {
+	var b strings.Builder
+
+	for {
+		loc := codeSpanRe.FindStringSubmatchIndex(text)
+		if loc == nil || text[loc[2]:loc[3]] != text[loc[6]:loc[7]] {
+			b.WriteString(r.emphasis(text))
+			break
+		}
+
+		b.WriteString(r.emphasis(text[:loc[0]]))
+		b.WriteString(r.style(styleCode, strings.TrimSpace(text[loc[4]:loc[5]])))
+		text = text[loc[1]:]
+	}
+
+	return b.String()
+}
+
+func (r *Renderer) emphasis(text string) string {
+	// links and escaped characters are replaced with placeholders, so that emphasis markers inside URLs are kept
+	placeholders := []string{}
+	hold := func(s string) string {
+		placeholders = append(placeholders, s)
+		return fmt.Sprintf("\x00%d\x00", len(placeholders)-1)
+	}
+
+	text = escapableRe.ReplaceAllStringFunc(text, func(s string) string {
+		return hold(s[1:])
+	})
+
+	text = imageRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := imageRe.FindStringSubmatch(s)
+		return hold(r.link(m[1], m[2]))
+	})
+
+	text = linkRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := linkRe.FindStringSubmatch(s)
+		return hold(r.link(m[1], m[2]))
+	})
+
+	text = autolinkRe.ReplaceAllStringFunc(text, func(s string) string {
+		url := autolinkRe.FindStringSubmatch(s)[1]
+		return hold(r.link(url, url))
+	})
+
+	text = boldRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := boldRe.FindStringSubmatch(s)
+		return r.style(styleBold, m[1]+m[2])
+	})
+
+	text = italicRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := italicRe.FindStringSubmatch(s)
+		if m[1] != "" {
+			return r.style(styleItalic, m[1])
+		}
+
+		return m[2] + r.style(styleItalic, m[3]) + m[4]
+	})
+
+	text = strikeRe.ReplaceAllString(text, "$1")
+
+	// link text may contain escaped characters, so placeholders can be nested
+	for placeholderRe.MatchString(text) {
+		text = placeholderRe.ReplaceAllStringFunc(text, func(s string) string {
+			n, _ := strconv.Atoi(strings.Trim(s, "\x00"))
+			return placeholders[n]
+		})
+	}
+
+	return text
+}
+
+func (r *Renderer) link(text, url string) string {
+	if text == "" {
+		text = url
+	}
+
+	if r.Hyperlinks {
+		return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, r.style(styleLink, text))
+	}
+
+	if text == url {
+		return r.style(styleLink, url)
+	}
+
+	return text + " (" + r.style(styleLink, url) + ")"
+}
+
+func (r *Renderer) style(style, text string) string {
+	if !r.Color || text == "" {
+		return text
+	}
+
+	return style + text + styleReset
+}
+
+func isTableRow(line string) bool {
+	return strings.Contains(line, "|") && strings.TrimSpace(line) != ""
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+
+	cells := []string{}
+	var b strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			b.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(b.String()))
+}
+
+// displayWidth returns the number of runes in text excluding escape sequences
+func displayWidth(text string) int {
+	return utf8.RuneCountInString(ansiRe.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestRender_plain(t *testing.T) {
+	r := &Renderer{}
+
+	testcases := []struct {
+		src  string
+		want string
+	}{
+		{
+			src:  "## Changelog",
+			want: "Changelog",
+		},
+		{
+			src:  "See [CHANGELOG](https://example.com/CHANGELOG.md#v152) for details.",
+			want: "See CHANGELOG (https://example.com/CHANGELOG.md#v152) for details.",
+		},
+		{
+			src:  "SHA256 for `kubernetes.tar.gz`: `6734`",
+			want: "SHA256 for kubernetes.tar.gz: 6734",
+		},
+		{
+			src:  "**Breaking** change in *api* and _web_, not in snake_case_name",
+			want: "Breaking change in api and web, not in snake_case_name",
+		},
+		{
+			src:  "Link with underscores [docs](https://example.com/a_b_c) and `**code**`",
+			want: "Link with underscores docs (https://example.com/a_b_c) and **code**",
+		},
+		{
+			src:  "<https://example.com> and ![logo](https://example.com/logo.png)",
+			want: "https://example.com and logo (https://example.com/logo.png)",
+		},
+		{
+			src:  "- item\n  * nested\n1. first\n- [x] done\n- [ ] todo",
+			want: "• item\n  • nested\n1. first\n☑ done\n☐ todo",
+		},
+		{
+			src:  "> quoted \\*text\\*",
+			want: "│ quoted *text*",
+		},
+		{
+			src:  "---",
+			want: "────────────────────────────────────────",
+		},
+		{
+			src:  "```go\nfunc main() {\n\t**x**\n}\n```\nafter",
+			want: "    func main() {\n    \t**x**\n    }\nafter",
+		},
+		{
+			src: "| OS | Arch | File |\n|----|:----:|-----:|\n| linux | amd64 | `a.tar.gz` |\n| darwin | arm64 |",
+			want: "" +
+				"OS     │ Arch  │ File\n" +
+				"───────┼───────┼─────────\n" +
+				"linux  │ amd64 │ a.tar.gz\n" +
+				"darwin │ arm64 │",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := r.Render(tc.src); got != tc.want {
+			t.Errorf("src: %q\nwant:\n%q\ngot:\n%q", tc.src, tc.want, got)
+		}
+	}
+}
+
+func TestRender_color(t *testing.T) {
+	testcases := []struct {
+		renderer *Renderer
+		src      string
+		want     string
+	}{
+		{
+			renderer: &Renderer{Color: true},
+			src:      "# Title",
+			want:     "\x1b[1m\x1b[4mTitle\x1b[0m",
+		},
+		{
+			renderer: &Renderer{Color: true},
+			src:      "use `ghrls`",
+			want:     "use \x1b[36mghrls\x1b[0m",
+		},
+		{
+			renderer: &Renderer{Color: true},
+			src:      "[docs](https://example.com)",
+			want:     "docs (\x1b[34;4mhttps://example.com\x1b[0m)",
+		},
+		{
+			renderer: &Renderer{Hyperlinks: true},
+			src:      "[docs](https://example.com)",
+			want:     "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\",
+		},
+	}
+
+	for _, tc := range testcases {
+		if got := tc.renderer.Render(tc.src); got != tc.want {
+			t.Errorf("src: %q\nwant:\n%q\ngot:\n%q", tc.src, tc.want, got)
+		}
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	testcases := []struct {
+		text string
+		want int
+	}{
+		{text: "abc", want: 3},
+		{text: "\x1b[1mabc\x1b[0m", want: 3},
+		{text: "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\", want: 4},
+		{text: "日本語", want: 3},
+	}
+
+	for _, tc := range testcases {
+		if got := displayWidth(tc.text); got != tc.want {
+			t.Errorf("%q want: %d, got: %d", tc.text, tc.want, got)
+		}
+	}
+}
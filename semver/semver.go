@@ -0,0 +1,133 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	versionRe = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// Version represents SemVer version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Pre is dot-separated pre-release identifiers, e.g. "rc.1"
+	Pre string
+	// Build is build metadata, which is ignored in comparison
+	Build string
+	// Prefix is "v" if the version is written like "v1.2.3"
+	Prefix string
+}
+
+// Parse parses SemVer version optionally prefixed by "v"
+func Parse(s string) (*Version, error) {
+	m := versionRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid semantic version: %s", s)
+	}
+
+	v := &Version{
+		Prefix: m[1],
+		Pre:    m[5],
+		Build:  m[6],
+	}
+
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.Atoi(m[i+2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid semantic version: %s", s)
+		}
+
+		*p = n
+	}
+
+	return v, nil
+}
+
+// String returns version string including prefix
+func (v *Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// IsPrerelease reports whether the version has pre-release identifiers
+func (v *Version) IsPrerelease() bool {
+	return v.Pre != ""
+}
+
+// Compare returns -1, 0 or 1 if v is lower than, equal to or higher than w in precedence
+func (v *Version) Compare(w *Version) int {
+	for _, d := range []int{v.Major - w.Major, v.Minor - w.Minor, v.Patch - w.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	return comparePre(v.Pre, w.Pre)
+}
+
+// comparePre compares pre-release identifiers
+// Version without pre-release has higher precedence than one with pre-release
+func comparePre(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+
+	return sign(len(as) - len(bs))
+}
+
+// compareIdentifier compares numeric identifiers numerically and others lexically
+// Numeric identifier has lower precedence than alphanumeric one
+func compareIdentifier(a, b string) int {
+	an, aerr := strconv.Atoi(a)
+	bn, berr := strconv.Atoi(b)
+
+	switch {
+	case aerr == nil && berr == nil:
+		return sign(an - bn)
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,105 @@
+package semver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testcases := []struct {
+		s    string
+		want *Version
+	}{
+		{
+			s:    "1.2.3",
+			want: &Version{Major: 1, Minor: 2, Patch: 3},
+		},
+		{
+			s:    "v0.10.0-rc.1+build.5",
+			want: &Version{Major: 0, Minor: 10, Patch: 0, Pre: "rc.1", Build: "build.5", Prefix: "v"},
+		},
+		{
+			s:    "v1.0.0-alpha-1.x",
+			want: &Version{Major: 1, Minor: 0, Patch: 0, Pre: "alpha-1.x", Prefix: "v"},
+		},
+	}
+
+	for _, tc := range testcases {
+		got, err := Parse(tc.s)
+		if err != nil {
+			t.Errorf("%s: want: no error, got: %s", tc.s, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: want: %#v, got: %#v", tc.s, tc.want, got)
+		}
+
+		if got.String() != tc.s {
+			t.Errorf("want: %s, got: %s", tc.s, got.String())
+		}
+	}
+}
+
+func TestParse_invalid(t *testing.T) {
+	testcases := []string{
+		"",
+		"1.2",
+		"1.2.3.4",
+		"01.2.3",
+		"1.2.3-01",
+		"1.2.3-",
+		"V1.2.3",
+		"release-1.2.3",
+	}
+
+	for _, s := range testcases {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%s: want: error, got: nil", s)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// in ascending order of precedence, taken from SemVer specification
+	versions := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"v1.0.1",
+		"1.1.0",
+		"2.0.0",
+		"10.0.0",
+	}
+
+	for i := range versions {
+		for j := range versions {
+			v, w := mustParse(t, versions[i]), mustParse(t, versions[j])
+
+			want := sign(i - j)
+			if got := v.Compare(w); got != want {
+				t.Errorf("compare %s with %s: want: %d, got: %d", versions[i], versions[j], want, got)
+			}
+		}
+	}
+
+	if got := mustParse(t, "1.0.0+a").Compare(mustParse(t, "v1.0.0+b")); got != 0 {
+		t.Errorf("build metadata and prefix must be ignored, got: %d", got)
+	}
+}
+
+func mustParse(t *testing.T, s string) *Version {
+	t.Helper()
+
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}